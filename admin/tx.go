@@ -42,6 +42,7 @@ var transferOnlyModeBlackList = []string{
 	swap.ClaimHTLTMsg{}.Type(),
 	swap.RefundHTLTMsg{}.Type(),
 	account.SetAccountFlagsMsg{}.Type(),
+	account.FeeConsentMsg{}.Type(),
 	bridge.BindMsg{}.Type(),
 	bridge.UnbindMsg{}.Type(),
 	bridge.TransferOutMsg{}.Type(),
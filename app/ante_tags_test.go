@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func tagValue(tags sdk.Tags, key string) string {
+	for _, tag := range tags {
+		if string(tag.Key) == key {
+			return string(tag.Value)
+		}
+	}
+	return ""
+}
+
+func TestDeliverAnteTags(t *testing.T) {
+	Codec = MakeCodec()
+	app := newBNBBeaconChainApp()
+	app.Codec.RegisterConcrete(&TestMsg{}, "cosmos-sdk/baseapp/testMsg", nil)
+	app.GetRouter().AddRoute("TestMsg", handleTestMsg())
+	msgType := newTestMsg().Type()
+	sdkfees.RegisterCalculator(msgType, sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	defer sdkfees.RegisterCalculator(msgType, nil)
+	defer sdkfees.Pool.Clear()
+	defer tx.FeePayers.Clear()
+
+	app.BeginBlock(abci.RequestBeginBlock{})
+	// the fees are not collected in the genesis block
+	app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockHeight(1)
+	priv1, addr1 := testutils.PrivAndAddr()
+	acc1 := app.AccountKeeper.NewAccountWithAddress(app.DeliverState.Ctx, addr1)
+	require.NoError(t, acc1.SetCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)}))
+	app.AccountKeeper.SetAccount(app.DeliverState.Ctx, acc1)
+
+	msg := newTestMsg(addr1)
+	txn := newTestTx(app.DeliverState.Ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{acc1.GetAccountNumber()}, []int64{0}, nil, "")
+	res := app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}.String(), tagValue(res.Tags, tx.TagFee))
+	require.Equal(t, "proposer", tagValue(res.Tags, tx.TagFeeDistribution))
+	require.Equal(t, msgType, tagValue(res.Tags, "action"))
+	require.Equal(t, addr1.String(), tagValue(res.Tags, tx.TagPubKeySet))
+
+	// the pubkey is only set by the first tx
+	txn = newTestTx(app.DeliverState.Ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{acc1.GetAccountNumber()}, []int64{1}, nil, "")
+	res = app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, tagValue(res.Tags, tx.TagPubKeySet))
+	require.NotEmpty(t, tagValue(res.Tags, tx.TagFee))
+
+	// all the signers whose pubkeys are set by the tx
+	priv2, addr2 := testutils.PrivAndAddr()
+	acc2 := app.AccountKeeper.NewAccountWithAddress(app.DeliverState.Ctx, addr2)
+	app.AccountKeeper.SetAccount(app.DeliverState.Ctx, acc2)
+	msg = newTestMsg(addr1, addr2)
+	txn = newTestTx(app.DeliverState.Ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1, priv2}, []int64{acc1.GetAccountNumber(), acc2.GetAccountNumber()}, []int64{2, 0}, nil, "")
+	res = app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, addr2.String(), tagValue(res.Tags, tx.TagPubKeysSet))
+}
@@ -115,6 +115,8 @@ type BNBBeaconChain struct {
 	scKeeper       sidechain.Keeper
 	// keeper to process param store and update
 	ParamHub *param.Keeper
+	// keeper of the consents of the recipients to pay the transfer fees
+	feeConsentKeeper tx.FeeConsentKeeper
 
 	baseConfig         *config.BaseConfig
 	upgradeConfig      *config.UpgradeConfig
@@ -202,6 +204,7 @@ func NewBNBBeaconChain(logger log.Logger, db dbm.DB, traceStore io.Writer, baseA
 		app.stakeKeeper, app.scKeeper, app.ibcKeeper, app.CoinKeeper, app.Pool)
 	app.bridgeKeeper = bridge.NewKeeper(cdc, common.BridgeStoreKey, app.AccountKeeper, app.TokenMapper, app.scKeeper, app.CoinKeeper,
 		app.ibcKeeper, app.Pool, sdk.ChainID(app.crossChainConfig.BscIbcChainId), app.crossChainConfig.BscChainId)
+	app.feeConsentKeeper = tx.NewFeeConsentKeeper(common.FeeConsentStoreKey)
 
 	if ServerContext.Config.Instrumentation.Prometheus {
 		app.metrics = pub.PrometheusMetrics() // TODO(#246): make it an aggregated wrapper of all component metrics (i.e. DexKeeper, StakeKeeper)
@@ -262,8 +265,11 @@ func NewBNBBeaconChain(logger log.Logger, db dbm.DB, traceStore io.Writer, baseA
 		common.OracleStoreKey,
 		common.IbcStoreKey,
 		common.ReconStoreKey,
+		common.FeeConsentStoreKey,
 	)
 	app.SetAnteHandler(tx.NewAnteHandler(app.AccountKeeper))
+	tx.SetValidatorChecker(app.ValAddrCache.IsSigningValidator)
+	tx.SetFeeConsentKeeper(&app.feeConsentKeeper)
 	app.SetPreChecker(tx.NewTxPreChecker())
 	app.MountStoresTransient(common.TParamsStoreKey, common.TStakeStoreKey)
 
@@ -290,6 +296,7 @@ func NewBNBBeaconChain(logger log.Logger, db dbm.DB, traceStore io.Writer, baseA
 	app.SetAccountStoreCache(cdc, accountStore, app.baseConfig.AccountCacheSize)
 
 	tx.InitSigCache(app.baseConfig.SignatureCacheSize)
+	tx.InitDuplicateTxCache(app.baseConfig.DuplicateTxCacheSize)
 
 	err = app.InitFromStore(common.MainStoreKey)
 	if err != nil {
@@ -365,6 +372,10 @@ func SetUpgradeConfig(upgradeConfig *config.UpgradeConfig) {
 	upgrade.Mgr.AddUpgradeHeight(upgrade.FirstSunset, upgradeConfig.FirstSunsetHeight)
 	upgrade.Mgr.AddUpgradeHeight(upgrade.SecondSunset, upgradeConfig.SecondSunsetHeight)
 	upgrade.Mgr.AddUpgradeHeight(upgrade.FinalSunset, upgradeConfig.FinalSunsetHeight)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, upgradeConfig.FixFeeDistributionHeight)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.RecipientPaysFee, upgradeConfig.RecipientPaysFeeHeight)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.DesignatedFeePayer, upgradeConfig.DesignatedFeePayerHeight)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.AccountNameCheck, upgradeConfig.AccountNameCheckHeight)
 
 	// register store keys of upgrade
 	upgrade.Mgr.RegisterStoreKeys(upgrade.BEP9, common.TimeLockStoreKey.Name())
@@ -373,6 +384,7 @@ func SetUpgradeConfig(upgradeConfig *config.UpgradeConfig) {
 		common.SlashingStoreKey.Name(), common.BridgeStoreKey.Name(), common.OracleStoreKey.Name())
 	upgrade.Mgr.RegisterStoreKeys(upgrade.BEP128, common.StakeRewardStoreKey.Name())
 	upgrade.Mgr.RegisterStoreKeys(upgrade.BEP255, common.ReconStoreKey.Name())
+	upgrade.Mgr.RegisterStoreKeys(upgrade.RecipientPaysFee, common.FeeConsentStoreKey.Name())
 
 	// register msg types of upgrade
 	upgrade.Mgr.RegisterMsgTypes(upgrade.BEP9,
@@ -419,6 +431,7 @@ func SetUpgradeConfig(upgradeConfig *config.UpgradeConfig) {
 	)
 
 	upgrade.Mgr.RegisterMsgTypes(upgrade.BEP82, ownership.TransferOwnershipMsg{}.Type())
+	upgrade.Mgr.RegisterMsgTypes(upgrade.RecipientPaysFee, account.FeeConsentMsg{}.Type())
 }
 
 func getABCIQueryBlackList(queryConfig *config.QueryConfig) map[string]bool {
@@ -443,6 +456,7 @@ func (app *BNBBeaconChain) initDex() {
 		app.publicationConfig.ShouldPublishAny())
 	app.DexKeeper.SubscribeParamChange(app.ParamHub)
 	app.DexKeeper.SetBUSDSymbol(app.dexConfig.BUSDSymbol)
+	tx.SetOpenOrderChecker(app.DexKeeper.HasOpenOrders)
 
 	// do not proceed if we are in a unit test and `CheckState` is unset.
 	if app.CheckState == nil {
@@ -479,13 +493,13 @@ func (app *BNBBeaconChain) initPlugins() {
 	app.initBridge()
 	tokens.InitPlugin(app, app.TokenMapper, app.AccountKeeper, app.CoinKeeper, app.timeLockKeeper, app.swapKeeper)
 	dex.InitPlugin(app, app.DexKeeper, app.TokenMapper, app.govKeeper)
-	account.InitPlugin(app, app.AccountKeeper)
+	account.InitPlugin(app, app.AccountKeeper, app.feeConsentKeeper)
 	bridge.InitPlugin(app, app.bridgeKeeper)
 	app.initParams()
 
 	// add handlers from bnc-cosmos-sdk (others moved to plugin init funcs)
 	// we need to add handlers after all keepers initialized
-	app.Router().
+	app.GetRouter().
 		AddRoute("bank", bank.NewHandler(app.CoinKeeper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper, app.govKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashKeeper)).
@@ -499,6 +513,7 @@ func (app *BNBBeaconChain) initPlugins() {
 	app.QueryRouter().AddRoute(swap.AtomicSwapRoute, swap.NewQuerier(app.swapKeeper))
 	app.QueryRouter().AddRoute("param", paramHub.NewQuerier(app.ParamHub, app.Codec))
 	app.QueryRouter().AddRoute("sideChain", sidechain.NewQuerier(app.scKeeper))
+	app.QueryRouter().AddRoute(tx.QueryRoute, tx.NewQuerier(app.Codec))
 
 	app.RegisterQueryHandler("account", app.AccountHandler)
 	app.RegisterQueryHandler("admin", admin.GetHandler(ServerContext.Config))
@@ -555,6 +570,14 @@ func (app *BNBBeaconChain) initParamHub() {
 	app.ParamHub.SetupForSideChain(&app.scKeeper, &app.ibcKeeper)
 
 	paramHub.RegisterUpgradeBeginBlocker(app.ParamHub)
+	// the fee consent msg is not known by the paramHub, add its fee param on the upgrade
+	fees.CalculatorsGen[account.FeeConsentMsgType] = fees.FixedFeeCalculatorGen
+	paramTypes.ValidFixedFeeMsgTypes[account.FeeConsentMsgType] = struct{}{}
+	upgrade.Mgr.RegisterBeginBlocker(upgrade.RecipientPaysFee, func(ctx sdk.Context) {
+		app.ParamHub.UpdateFeeParams(ctx, []paramTypes.FeeParam{
+			&paramTypes.FixedFeeParams{MsgType: account.FeeConsentMsgType, Fee: paramHub.SetAccountFlagsFee, FeeFor: sdk.FeeForProposer},
+		})
+	})
 	upgrade.Mgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
 		app.scKeeper.SetChannelSendPermission(ctx, sdk.ChainID(ServerContext.BscIbcChainId), param.ChannelId, sdk.ChannelAllow)
 		storePrefix := app.scKeeper.GetSideChainStorePrefix(ctx, ServerContext.BscChainId)
@@ -748,6 +771,7 @@ func (app *BNBBeaconChain) initParams() {
 // initChainerFn performs custom logic for chain initialization.
 func (app *BNBBeaconChain) initChainerFn() sdk.InitChainer {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		types.LockNativeToken()
 		stateJSON := req.AppStateBytes
 
 		genesisState := new(GenesisState)
@@ -757,14 +781,12 @@ func (app *BNBBeaconChain) initChainerFn() sdk.InitChainer {
 			// return sdk.ErrGenesisParse("").TraceCause(err, "")
 		}
 
+		CreateGenesisAccounts(ctx, app.AccountKeeper, genesisState.Accounts)
 		selfDelegationAddrs := make([]sdk.AccAddress, 0, len(genesisState.Accounts))
 		for _, gacc := range genesisState.Accounts {
-			acc := gacc.ToAppAccount()
-			acc.AccountNumber = app.AccountKeeper.GetNextAccountNumber(ctx)
-			app.AccountKeeper.SetAccount(ctx, acc)
 			// this relies on that the non-operator addresses are all used for self-delegation
 			if len(gacc.ConsensusAddr) == 0 {
-				selfDelegationAddrs = append(selfDelegationAddrs, acc.Address)
+				selfDelegationAddrs = append(selfDelegationAddrs, gacc.Address)
 			}
 		}
 		tokens.InitGenesis(ctx, app.TokenMapper, app.CoinKeeper, genesisState.Tokens,
@@ -866,6 +888,7 @@ func (app *BNBBeaconChain) DeliverTx(req abci.RequestDeliverTx) (res abci.Respon
 	if res.IsOK() {
 		// commit or panic
 		fees.Pool.CommitFee(txHash)
+		tx.FeePayers.Commit(txHash)
 		if app.psServer != nil {
 			app.psServer.Publish(appsub.TxDeliverSuccEvent{})
 		}
@@ -906,6 +929,7 @@ func (app *BNBBeaconChain) isBreatheBlock(height int64, lastBlockTime time.Time,
 
 func (app *BNBBeaconChain) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
 	upgrade.Mgr.BeginBlocker(ctx)
+	app.ValAddrCache.RecordSigningValidators(ctx)
 	return
 }
 
@@ -991,6 +1015,7 @@ func (app *BNBBeaconChain) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock)
 		appsub.Clear()
 	}
 	fees.Pool.Clear()
+	tx.FeePayers.Clear()
 	// just clean it, no matter use it or not.
 	pub.Pool.Clean()
 	// match may end with transaction failure, which is better to save into
@@ -1130,9 +1155,10 @@ func (app *BNBBeaconChain) GetCodec() *wire.Codec {
 	return app.Codec
 }
 
-// GetRouter returns the app's Router.
+// GetRouter returns the app's Router, the tags of the ante handler are added to the result of the handlers added to it,
+// and the accounts they create are counted.
 func (app *BNBBeaconChain) GetRouter() baseapp.Router {
-	return app.Router()
+	return tx.NewAnteTagsRouter(tx.NewAccountLimitRouter(app.Router(), app.AccountKeeper, tx.DefaultAnteOptions()))
 }
 
 // GetContextForCheckState gets the context for the check state.
@@ -37,6 +37,8 @@ breatheBlockInterval = {{ .BaseConfig.BreatheBlockInterval }}
 accountCacheSize = {{ .BaseConfig.AccountCacheSize }}
 # Size of signature cache
 signatureCacheSize = {{ .BaseConfig.SignatureCacheSize }}
+# Size of the cache of recently checked txs, duplicates of which are rejected in CheckTx. 0 disables it
+duplicateTxCacheSize = {{ .BaseConfig.DuplicateTxCacheSize }}
 # Running mode when start up, 0: Normal, 1: TransferOnly, 2: RecoverOnly
 startMode = {{ .BaseConfig.StartMode }}
 # Concurrency of OrderKeeper, should be power of 2
@@ -109,6 +111,14 @@ FirstSunsetHeight = {{ .UpgradeConfig.FirstSunsetHeight }}
 SecondSunsetHeight = {{ .UpgradeConfig.SecondSunsetHeight }}
 # Block height of FinalSunset upgrade
 FinalSunsetHeight = {{ .UpgradeConfig.FinalSunsetHeight }}
+# Block height of FixFeeDistribution upgrade
+FixFeeDistributionHeight = {{ .UpgradeConfig.FixFeeDistributionHeight }}
+# Block height of RecipientPaysFee upgrade
+RecipientPaysFeeHeight = {{ .UpgradeConfig.RecipientPaysFeeHeight }}
+# Block height of DesignatedFeePayer upgrade
+DesignatedFeePayerHeight = {{ .UpgradeConfig.DesignatedFeePayerHeight }}
+# Block height of AccountNameCheck upgrade
+AccountNameCheckHeight = {{ .UpgradeConfig.AccountNameCheckHeight }}
 
 [query]
 # ABCI query interface black list, suggested value: ["custom/gov/proposals", "custom/timelock/timelocks", "custom/atomicSwap/swapcreator", "custom/atomicSwap/swaprecipient"]
@@ -504,6 +514,7 @@ func defaultLogConfig() *LogConfig {
 type BaseConfig struct {
 	AccountCacheSize          int   `mapstructure:"accountCacheSize"`
 	SignatureCacheSize        int   `mapstructure:"signatureCacheSize"`
+	DuplicateTxCacheSize      int   `mapstructure:"duplicateTxCacheSize"`
 	StartMode                 uint8 `mapstructure:"startMode"`
 	BreatheBlockInterval      int   `mapstructure:"breatheBlockInterval"`
 	OrderKeeperConcurrency    uint  `mapstructure:"orderKeeperConcurrency"`
@@ -514,6 +525,7 @@ func defaultBaseConfig() *BaseConfig {
 	return &BaseConfig{
 		AccountCacheSize:          30000,
 		SignatureCacheSize:        30000,
+		DuplicateTxCacheSize:      0,
 		StartMode:                 0,
 		BreatheBlockInterval:      0,
 		OrderKeeperConcurrency:    2,
@@ -561,6 +573,10 @@ type UpgradeConfig struct {
 	FirstSunsetHeight                               int64 `mapstructure:"FirstSunsetHeight"`
 	SecondSunsetHeight                              int64 `mapstructure:"SecondSunsetHeight"`
 	FinalSunsetHeight                               int64 `mapstructure:"FinalSunsetHeight"`
+	FixFeeDistributionHeight                        int64 `mapstructure:"FixFeeDistributionHeight"`
+	RecipientPaysFeeHeight                          int64 `mapstructure:"RecipientPaysFeeHeight"`
+	DesignatedFeePayerHeight                        int64 `mapstructure:"DesignatedFeePayerHeight"`
+	AccountNameCheckHeight                          int64 `mapstructure:"AccountNameCheckHeight"`
 }
 
 func defaultUpgradeConfig() *UpgradeConfig {
@@ -599,6 +615,11 @@ func defaultUpgradeConfig() *UpgradeConfig {
 		FirstSunsetHeight:  math.MaxInt64,
 		SecondSunsetHeight: math.MaxInt64,
 		FinalSunsetHeight:  math.MaxInt64,
+
+		FixFeeDistributionHeight: math.MaxInt64,
+		RecipientPaysFeeHeight:   math.MaxInt64,
+		DesignatedFeePayerHeight: math.MaxInt64,
+		AccountNameCheckHeight:   math.MaxInt64,
 	}
 }
 
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	pHub "github.com/cosmos/cosmos-sdk/x/paramHub"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/common/upgrade"
+	"github.com/bnb-chain/node/plugins/account"
+	"github.com/bnb-chain/node/plugins/dex"
+	"github.com/bnb-chain/node/plugins/tokens"
+	"github.com/bnb-chain/node/plugins/tokens/swap"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestHTLTFeePaidByConsentedRecipient(t *testing.T) {
+	ServerContext.UpgradeConfig.RecipientPaysFeeHeight = 1
+	defer func() { ServerContext.UpgradeConfig.RecipientPaysFeeHeight = math.MaxInt64 }()
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.RecipientPaysFee, math.MaxInt64)
+	defer sdkfees.Pool.Clear()
+	defer tx.FeePayers.Clear()
+	app := newBNBBeaconChainApp()
+
+	_, genAddr := testutils.PrivAndAddr()
+	genAcc := &types.AppAccount{BaseAccount: auth.BaseAccount{Address: genAddr}}
+	genesisState := GenesisState{
+		Tokens:       []tokens.GenesisToken{{"BNB", "BNB", 100000000e8, genAddr, false}},
+		Accounts:     []GenesisAccount{NewGenesisAccount(genAcc, ed25519.GenPrivKey().PubKey().Address())},
+		DexGenesis:   dex.DefaultGenesis,
+		ParamGenesis: pHub.DefaultGenesisState,
+	}
+	stateBytes, err := wire.MarshalJSONIndent(app.Codec, genesisState)
+	require.NoError(t, err)
+	app.InitChain(abci.RequestInitChain{AppStateBytes: stateBytes})
+
+	// the fee param of the consent msg is added on the upgrade
+	now := time.Now()
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1, Time: now}})
+	ctx := app.DeliverState.Ctx
+	require.NotNil(t, sdkfees.GetCalculator(account.FeeConsentMsgType))
+
+	priv1, addr1 := testutils.PrivAndAddr()
+	priv2, addr2 := testutils.PrivAndAddr()
+	for _, addr := range []sdk.AccAddress{addr1, addr2} {
+		acc := app.AccountKeeper.NewAccountWithAddress(ctx, addr)
+		require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100e8)}))
+		app.AccountKeeper.SetAccount(ctx, acc)
+	}
+	accNum1 := app.AccountKeeper.GetAccount(ctx, addr1).GetAccountNumber()
+	accNum2 := app.AccountKeeper.GetAccount(ctx, addr2).GetAccountNumber()
+
+	randomNumberHash, _ := hex.DecodeString("be543130668282f267580badb1c956dacd4502be3b57846443c9921118ffa167")
+	htlt := swap.NewHTLTMsg(addr1, addr2, "", "", randomNumberHash, now.Unix(),
+		sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, "10:BNB", 1000, false)
+	htlt.RecipientPaysFee = true
+
+	// the recipient hasn't consented
+	txn := newTestTx(ctx, []sdk.Msg{htlt}, []crypto.PrivKey{priv1}, []int64{accNum1}, []int64{0}, nil, "")
+	res := app.Deliver(txn)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code, res.Log)
+
+	consent := account.NewFeeConsentMsg(addr2, addr1, false)
+	txn = newTestTx(ctx, []sdk.Msg{consent}, []crypto.PrivKey{priv2}, []int64{accNum2}, []int64{0}, nil, "")
+	res = app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, app.feeConsentKeeper.IsFeePaymentAuthorized(ctx, addr2, addr1))
+	consentFee := sdkfees.GetCalculator(consent.Type())(consent).Tokens.AmountOf(types.NativeTokenSymbol)
+
+	txn = newTestTx(ctx, []sdk.Msg{htlt}, []crypto.PrivKey{priv1}, []int64{accNum1}, []int64{0}, nil, "")
+	res = app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	htltFee := sdkfees.GetCalculator(htlt.Type())(htlt).Tokens.AmountOf(types.NativeTokenSymbol)
+
+	require.Equal(t, int64(100e8-10), app.AccountKeeper.GetAccount(ctx, addr1).GetCoins().AmountOf(types.NativeTokenSymbol))
+	require.Equal(t, int64(100e8)-consentFee-htltFee, app.AccountKeeper.GetAccount(ctx, addr2).GetCoins().AmountOf(types.NativeTokenSymbol))
+
+	// the recipient revokes the consent
+	consent = account.NewFeeConsentMsg(addr2, addr1, true)
+	txn = newTestTx(ctx, []sdk.Msg{consent}, []crypto.PrivKey{priv2}, []int64{accNum2}, []int64{1}, nil, "")
+	res = app.Deliver(txn)
+	require.True(t, res.IsOK(), res.Log)
+	require.False(t, app.feeConsentKeeper.IsFeePaymentAuthorized(ctx, addr2, addr1))
+}
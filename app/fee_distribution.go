@@ -3,6 +3,7 @@ package app
 import (
 	"bytes"
 	"fmt"
+	"sync"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/bnb-chain/node/app/pub"
 	"github.com/bnb-chain/node/common/log"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/upgrade"
 )
 
 func NewValAddrCache(stakeKeeper stake.Keeper) *ValAddrCache {
@@ -27,6 +30,11 @@ type ValAddrCache struct {
 	cache                 map[string]sdk.AccAddress
 	distributionAddrCache map[string]sdk.AccAddress
 	stakeKeeper           stake.Keeper
+
+	// the fee addresses of the validators which signed the last block, recorded by RecordSigningValidators,
+	// read by the ante handler in CheckTx as well as DeliverTx
+	signingMtx        sync.RWMutex
+	signingValidators map[string]bool
 }
 
 func (vac *ValAddrCache) ClearCache() {
@@ -51,6 +59,29 @@ func (vac *ValAddrCache) GetAccAddr(ctx sdk.Context, consAddr sdk.ConsAddress) s
 	return accAddr
 }
 
+// RecordSigningValidators records the validators which signed the last block by the vote infos of the ctx,
+// it's called in BeginBlocker, where the vote infos are set.
+func (vac *ValAddrCache) RecordSigningValidators(ctx sdk.Context) {
+	signing := make(map[string]bool)
+	for _, voteInfo := range ctx.VoteInfos() {
+		if voteInfo.SignedLastBlock {
+			signing[string(vac.GetAccAddr(ctx, voteInfo.Validator.Address))] = true
+		}
+	}
+	vac.signingMtx.Lock()
+	vac.signingValidators = signing
+	vac.signingMtx.Unlock()
+}
+
+// IsSigningValidator tells whether the account is the fee address of a validator which signed the last block,
+// as recorded by RecordSigningValidators. The vote infos of the ctx are not used, as they are not set in CheckTx,
+// so CheckTx and DeliverTx agree on the validators until the next block begins.
+func (vac *ValAddrCache) IsSigningValidator(ctx sdk.Context, addr sdk.AccAddress) bool {
+	vac.signingMtx.RLock()
+	defer vac.signingMtx.RUnlock()
+	return vac.signingValidators[string(addr)]
+}
+
 func (vac *ValAddrCache) SetDistributionAddr(consAddr sdk.ConsAddress, accAddr sdk.AccAddress) {
 	vac.distributionAddrCache[string(consAddr)] = accAddr
 }
@@ -88,11 +119,158 @@ func distributeFeeBEP159(ctx sdk.Context, am auth.AccountKeeper, valAddrCache *V
 		return
 	}
 
-	// distrubute proposer rewards
+	distributor := bep159Distributor
+	if distributor == nil {
+		distributor = bep159FeeDistributor{
+			baseProposerRewardRatio:  stakeKeeper.BaseProposerRewardRatio(ctx),
+			bonusProposerRewardRatio: stakeKeeper.BonusProposerRewardRatio(ctx),
+		}
+	}
+	fee := sdk.NewFee(prevBlockFee, sdk.FeeForAll)
+	shares := distributor.Distribute(ctx, fee, []sdk.AccAddress{stake.FeeForAllAccAddr}, prevProposerDistributionAddr)
+	if len(shares) == 0 && undistributableFeeSink != nil {
+		// none of the recipients is eligible. The payers were charged in the previous block and can't be refunded,
+		// the fee goes to the sink if any, or stays in the fee collector to be distributed in the next block.
+		shares = []FeeShare{{Addr: undistributableFeeSink, Tokens: fee.Tokens}}
+	}
+	if checkFeeDistribution && len(shares) != 0 {
+		mustDistributeAll(fee, shares)
+	}
+	for _, share := range shares {
+		addr := share.Addr
+		if len(addr) == 0 && sdk.IsUpgrade(upgrade.FixFeeDistribution) {
+			// e.g. the previous proposer had no distribution address, the bank keeper would create an account
+			// for the empty address. The share goes to the sink if any, or stays in the fee collector.
+			if undistributableFeeSink == nil {
+				continue
+			}
+			addr = undistributableFeeSink
+		}
+		if _, err := stakeKeeper.BankKeeper.SendCoins(ctx, stake.FeeCollectorAddr, addr, share.Tokens); err != nil {
+			panic(err)
+		}
+	}
+	ctx.Logger().Info("FeeCalculation distributeFeeBEP159", "prevProposerDistributionAddr", prevProposerDistributionAddr, "shares", shares)
+
+	// TODO: design event for fee distribution
+	//if publishBlockFee {
+	//	blockFee.Fee = fee.String()
+	//	for _, validator := range currentValidators {
+	//		blockFee.Validators = append(blockFee.Validators, validator.ConsAddress().String())
+	//	}
+	//}
+	return
+}
+
+// FeeShare is the part of the fee credited to a single recipient.
+type FeeShare struct {
+	Addr   sdk.AccAddress
+	Tokens sdk.Coins
+}
+
+// FeeDistributor decides how the fee collected in a block is split. It returns one share per recipient, validators
+// sharing the same account get a share each, and the shares are credited in the returned order.
+type FeeDistributor interface {
+	Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare
+}
+
+var feeDistributors = map[sdk.FeeDistributeType]FeeDistributor{
+	sdk.FeeForProposer: proposerFeeDistributor{},
+	sdk.FeeForAll:      allValidatorsFeeDistributor{},
+}
+
+// RegisterFeeDistributor registers the distributor used for the fee of the given distribute type,
+// the existing one will be replaced.
+func RegisterFeeDistributor(feeType sdk.FeeDistributeType, distributor FeeDistributor) {
+	feeDistributors[feeType] = distributor
+}
+
+func GetFeeDistributor(feeType sdk.FeeDistributeType) FeeDistributor {
+	return feeDistributors[feeType]
+}
+
+// the distributor of the fee collected after BEP159, nil means splitting it by the stake params
+var bep159Distributor FeeDistributor
+
+// SetBEP159FeeDistributor replaces the distributor of the fee collected after BEP159, which is given the fee-for-all
+// account as the only validator and the distribution address of the previous proposer. nil restores the default one.
+func SetBEP159FeeDistributor(distributor FeeDistributor) {
+	bep159Distributor = distributor
+}
+
+type proposerFeeDistributor struct{}
+
+func (proposerFeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
+	return []FeeShare{{Addr: proposer, Tokens: fee.Tokens}}
+}
+
+type allValidatorsFeeDistributor struct{}
+
+func (allValidatorsFeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
+	valSize := int64(len(validators))
+	log.Info("Distributing the fees to all the validators",
+		"totalFees", fee.Tokens, "validatorSize", valSize)
+	if valSize == 0 {
+		if sdk.IsUpgrade(upgrade.FixFeeDistribution) {
+			// none of the validators signed the last block
+			return nil
+		}
+		return []FeeShare{{Addr: proposer, Tokens: fee.Tokens}}
+	}
+
+	avgTokens := sdk.Coins{}
+	roundingTokens := sdk.Coins{}
+	for _, token := range fee.Tokens {
+		amount := token.Amount
+		avgAmount := amount / valSize
+		roundingAmount := amount - avgAmount*valSize
+		if avgAmount != 0 {
+			avgTokens = append(avgTokens, sdk.NewCoin(token.Denom, avgAmount))
+		}
+
+		if roundingAmount != 0 {
+			roundingTokens = append(roundingTokens, sdk.NewCoin(token.Denom, roundingAmount))
+		}
+	}
+
+	if avgTokens.IsZero() {
+		return []FeeShare{{Addr: proposer, Tokens: fee.Tokens}}
+	}
+
+	// the proposer's share comes first, then the other validators in vote order
+	shares := make([]FeeShare, 0, valSize)
+	proposerIdx := -1
+	for i, validator := range validators {
+		if proposerIdx < 0 && validator.Equals(proposer) {
+			proposerIdx = i
+			shares = append([]FeeShare{{Addr: validator, Tokens: avgTokens}}, shares...)
+			continue
+		}
+		shares = append(shares, FeeShare{Addr: validator, Tokens: avgTokens})
+	}
+	if !roundingTokens.IsZero() {
+		if proposerIdx >= 0 {
+			shares[0].Tokens = shares[0].Tokens.Plus(roundingTokens)
+		} else if sdk.IsUpgrade(upgrade.FixFeeDistribution) {
+			// the proposer didn't vote, the rounding used to be dropped. It goes to the validator with the lowest
+			// address instead, so that it's never lost and doesn't depend on the validators order.
+			lowest := lowestAddressShare(shares)
+			shares[lowest].Tokens = shares[lowest].Tokens.Plus(roundingTokens)
+		}
+	}
+	return shares
+}
+
+// bep159FeeDistributor gives the proposer a base reward plus a bonus proportional to the signing validators,
+// the rest goes to the fee-for-all account.
+type bep159FeeDistributor struct {
+	baseProposerRewardRatio  sdk.Dec
+	bonusProposerRewardRatio sdk.Dec
+}
+
+func (d bep159FeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
 	proposerRewards := sdk.Coins{}
 	feeForAllRewards := sdk.Coins{}
-	var baseProposerRewardRatio sdk.Dec = stakeKeeper.BaseProposerRewardRatio(ctx)
-	var bonusProposerRewardRatio sdk.Dec = stakeKeeper.BonusProposerRewardRatio(ctx)
 	validatorNum := int64(len(ctx.VoteInfos()))
 	var voteNum int64 = 0
 	for _, voteInfo := range ctx.VoteInfos() {
@@ -100,39 +278,88 @@ func distributeFeeBEP159(ctx sdk.Context, am auth.AccountKeeper, valAddrCache *V
 			voteNum++
 		}
 	}
-	ctx.Logger().Info("FeeCalculation distributeFeeBEP159", "voteNum", voteNum, "validatorNum", validatorNum, "baseProposerRewardRatio", baseProposerRewardRatio, "bonusProposerRewardRatio", bonusProposerRewardRatio)
-	for _, token := range prevBlockFee {
+	ctx.Logger().Info("FeeCalculation distributeFeeBEP159", "voteNum", voteNum, "validatorNum", validatorNum, "baseProposerRewardRatio", d.baseProposerRewardRatio, "bonusProposerRewardRatio", d.bonusProposerRewardRatio)
+	for _, token := range fee.Tokens {
 		amount := sdk.NewDec(token.Amount)
-		baseProposerReward := amount.Mul(baseProposerRewardRatio)
+		baseProposerReward := amount.Mul(d.baseProposerRewardRatio)
 		var bonusProposerReward sdk.Dec
 		if validatorNum == 0 {
 			// at the first breath block after BEP159 activation, the validator snapshot is empty
 			// we distribute the bonusProposerReward to proposer directly (voteNum should never be 0)
-			bonusProposerReward = amount.Mul(bonusProposerRewardRatio)
+			bonusProposerReward = amount.Mul(d.bonusProposerRewardRatio)
 		} else {
-			bonusProposerReward = amount.Mul(bonusProposerRewardRatio).MulInt(voteNum).QuoInt(validatorNum)
+			bonusProposerReward = amount.Mul(d.bonusProposerRewardRatio).MulInt(voteNum).QuoInt(validatorNum)
 		}
+		// the amounts are in the smallest unit, the proposer's reward is rounded and the fee-for-all account
+		// takes exactly what's left, so no remainder is left in the fee collector
 		proposerAmount := baseProposerReward.Add(bonusProposerReward)
 		proposerRewards = append(proposerRewards, sdk.NewCoin(token.Denom, proposerAmount.RawInt()))
 		feeForAllRewards = append(feeForAllRewards, sdk.NewCoin(token.Denom, amount.Sub(proposerAmount).RawInt()))
 	}
-	var err error
-	if _, err = stakeKeeper.BankKeeper.SendCoins(ctx, stake.FeeCollectorAddr, stake.FeeForAllAccAddr, feeForAllRewards); err != nil {
-		panic(err)
+	return []FeeShare{
+		{Addr: validators[0], Tokens: feeForAllRewards},
+		{Addr: proposer, Tokens: proposerRewards},
 	}
-	if _, err = stakeKeeper.BankKeeper.SendCoins(ctx, stake.FeeCollectorAddr, prevProposerDistributionAddr, proposerRewards); err != nil {
-		panic(err)
+}
+
+func lowestAddressShare(shares []FeeShare) int {
+	lowest := 0
+	for i := 1; i < len(shares); i++ {
+		if bytes.Compare(shares[i].Addr, shares[lowest].Addr) < 0 {
+			lowest = i
+		}
 	}
-	ctx.Logger().Info("FeeCalculation distributeFeeBEP159", "prevProposerDistributionAddr", prevProposerDistributionAddr, "proposerRewards", proposerRewards, "feeForAllRewards", feeForAllRewards)
+	return lowest
+}
 
-	// TODO: design event for fee distribution
-	//if publishBlockFee {
-	//	blockFee.Fee = fee.String()
-	//	for _, validator := range currentValidators {
-	//		blockFee.Validators = append(blockFee.Validators, validator.ConsAddress().String())
-	//	}
-	//}
-	return
+// whether to assert the distributed amounts sum to the collected fee, meant to be turned on in tests
+var checkFeeDistribution bool
+
+// SetFeeDistributionCheck turns on the assertion that the fee distribution sums exactly to the collected fee,
+// which panics on a mismatch. Distributors burning a part of the fee should not be used with it.
+func SetFeeDistributionCheck(check bool) {
+	checkFeeDistribution = check
+}
+
+func mustDistributeAll(fee sdk.Fee, shares []FeeShare) {
+	total := sdk.Coins{}
+	for _, share := range shares {
+		total = total.Plus(share.Tokens)
+	}
+	if !total.IsEqual(fee.Tokens) {
+		panic(fmt.Errorf("fee distribution mismatch, collected %s but distributed %s", fee.Tokens, total))
+	}
+}
+
+// the account receiving the fee that no recipient is eligible for, nil means the fee will be refunded to the payers.
+var undistributableFeeSink sdk.AccAddress
+
+// SetUndistributableFeeSink routes the fee that can't be distributed to the given account (e.g. the community pool),
+// instead of refunding it to the payers.
+func SetUndistributableFeeSink(addr sdk.AccAddress) {
+	undistributableFeeSink = addr
+}
+
+func undistributableFee(fee sdk.Fee, proposer sdk.AccAddress) []FeeShare {
+	if undistributableFeeSink != nil {
+		return []FeeShare{{Addr: undistributableFeeSink, Tokens: fee.Tokens}}
+	}
+
+	refunds := make([]FeeShare, 0)
+	remaining := fee.Tokens
+	for _, payment := range tx.FeePayers.Committed() {
+		refund := payment.Fee
+		if !remaining.Minus(refund).IsNotNegative() {
+			continue
+		}
+		remaining = remaining.Minus(refund)
+		refunds = append(refunds, FeeShare{Addr: payment.Payer, Tokens: refund})
+	}
+	// the fee not paid by any tx (e.g. dex fees of the block) still goes to the proposer
+	if !remaining.IsZero() {
+		refunds = append(refunds, FeeShare{Addr: proposer, Tokens: remaining})
+	}
+	return refunds
 }
 
 func distributeFee(ctx sdk.Context, am auth.AccountKeeper, valAddrCache *ValAddrCache, publishBlockFee bool) (blockFee pub.BlockFee) {
@@ -146,55 +373,51 @@ func distributeFee(ctx sdk.Context, am auth.AccountKeeper, valAddrCache *ValAddr
 	proposerValAddr := ctx.BlockHeader().ProposerAddress
 	proposerAccAddr := valAddrCache.GetAccAddr(ctx, proposerValAddr)
 	voteInfos := ctx.VoteInfos()
-	valSize := int64(len(voteInfos))
+	validatorAccAddrs := make([]sdk.AccAddress, 0, len(voteInfos))
+	for _, voteInfo := range voteInfos {
+		if !voteInfo.SignedLastBlock && sdk.IsUpgrade(upgrade.FixFeeDistribution) {
+			// only the validators which signed the last block are eligible
+			continue
+		}
+		validatorAccAddrs = append(validatorAccAddrs, valAddrCache.GetAccAddr(ctx, voteInfo.Validator.Address))
+	}
+
+	distributor := GetFeeDistributor(fee.Type)
+	if distributor == nil {
+		ctx.Logger().Error("no fee distributor registered", "feeType", fee.Type)
+		return
+	}
+	shares := distributor.Distribute(ctx, fee, validatorAccAddrs, proposerAccAddr)
+	if len(shares) == 0 {
+		// none of the recipients is eligible, the fee should not vanish
+		shares = undistributableFee(fee, proposerAccAddr)
+	}
+	if checkFeeDistribution {
+		mustDistributeAll(fee, shares)
+	}
+
 	var validators []string
 	if publishBlockFee {
-		validators = make([]string, 0, valSize)
+		validators = make([]string, 0, len(shares)+1)
 		validators = append(validators, string(proposerAccAddr)) // the first validator to publish should be proposer
 	}
-
-	if fee.Type == sdk.FeeForProposer {
-		// The proposer's account must be initialized before it becomes a proposer.
-		proposerAcc := am.GetAccount(ctx, proposerAccAddr)
-		_ = proposerAcc.SetCoins(proposerAcc.GetCoins().Plus(fee.Tokens))
-		am.SetAccount(ctx, proposerAcc)
-	} else if fee.Type == sdk.FeeForAll {
-		log.Info("Distributing the fees to all the validators",
-			"totalFees", fee.Tokens, "validatorSize", valSize)
-		avgTokens := sdk.Coins{}
-		roundingTokens := sdk.Coins{}
-		for _, token := range fee.Tokens {
-			amount := token.Amount
-			avgAmount := amount / valSize
-			roundingAmount := amount - avgAmount*valSize
-			if avgAmount != 0 {
-				avgTokens = append(avgTokens, sdk.NewCoin(token.Denom, avgAmount))
-			}
-
-			if roundingAmount != 0 {
-				roundingTokens = append(roundingTokens, sdk.NewCoin(token.Denom, roundingAmount))
+	for i, share := range shares {
+		acc := am.GetAccount(ctx, share.Addr)
+		if acc == nil {
+			if !sdk.IsUpgrade(upgrade.FixFeeDistribution) {
+				// The recipient's account must be initialized before it becomes a proposer or validator.
+				panic(fmt.Errorf("fee recipient %s has no account", share.Addr))
 			}
+			// create it so the fee is still credited
+			acc = am.NewAccountWithAddress(ctx, share.Addr)
 		}
-
-		if avgTokens.IsZero() {
-			proposerAcc := am.GetAccount(ctx, proposerAccAddr)
-			_ = proposerAcc.SetCoins(proposerAcc.GetCoins().Plus(fee.Tokens))
-			am.SetAccount(ctx, proposerAcc)
-		} else {
-			for _, voteInfo := range voteInfos {
-				validator := voteInfo.Validator
-				accAddr := valAddrCache.GetAccAddr(ctx, validator.Address)
-				validatorAcc := am.GetAccount(ctx, accAddr)
-				if bytes.Equal(proposerValAddr, validator.Address) {
-					if !roundingTokens.IsZero() {
-						_ = validatorAcc.SetCoins(validatorAcc.GetCoins().Plus(roundingTokens))
-					}
-				} else if publishBlockFee {
-					validators = append(validators, string(accAddr))
-				}
-				_ = validatorAcc.SetCoins(validatorAcc.GetCoins().Plus(avgTokens))
-				am.SetAccount(ctx, validatorAcc)
-			}
+		if err := acc.SetCoins(acc.GetCoins().Plus(share.Tokens)); err != nil {
+			// the fee was taken from the accounts, so the coins can't overflow unless there is a bug
+			panic(fmt.Errorf("failed to credit the fee to %s: %v", share.Addr, err))
+		}
+		am.SetAccount(ctx, acc)
+		if publishBlockFee && !(i == 0 && share.Addr.Equals(proposerAccAddr)) {
+			validators = append(validators, string(share.Addr))
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	stakekeeper "github.com/cosmos/cosmos-sdk/x/stake/keeper"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
@@ -22,6 +24,7 @@ import (
 
 	"github.com/bnb-chain/node/app/pub"
 	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
 	"github.com/bnb-chain/node/common/types"
 	"github.com/bnb-chain/node/common/upgrade"
 	"github.com/bnb-chain/node/wire"
@@ -70,6 +73,21 @@ func setup() (am auth.AccountKeeper, valAddrCache *ValAddrCache, ctx sdk.Context
 	return
 }
 
+func TestIsSigningValidator(t *testing.T) {
+	_, valAddrCache, ctx, proposerAcc, valAcc1, _, _ := setup()
+	votes := ctx.VoteInfos()
+	votes[1].SignedLastBlock = false
+	ctx = ctx.WithVoteInfos(votes)
+	valAddrCache.RecordSigningValidators(ctx)
+
+	// the vote infos are not set in CheckTx
+	checkCtx := ctx.WithVoteInfos(nil).WithRunTxMode(sdk.RunTxModeCheck)
+	for _, c := range []sdk.Context{ctx, checkCtx} {
+		require.True(t, valAddrCache.IsSigningValidator(c, proposerAcc.GetAddress()))
+		require.False(t, valAddrCache.IsSigningValidator(c, valAcc1.GetAddress()))
+	}
+}
+
 func checkBalance(t *testing.T, ctx sdk.Context, am auth.AccountKeeper, valAddrCache *ValAddrCache, balances []int64) {
 	for i, voteInfo := range ctx.VoteInfos() {
 		accAddr := valAddrCache.GetAccAddr(ctx, voteInfo.Validator.Address)
@@ -100,6 +118,26 @@ func TestFeeDistribution2Proposer(t *testing.T) {
 	checkBalance(t, ctx, am, valAddrCache, []int64{110, 100, 100, 100})
 }
 
+func TestFeeDistribution2ProposerWithoutAccount(t *testing.T) {
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	// the proposer has no account yet
+	_, proposerAddr := testutils.PrivAndAddr()
+	valAddrCache.SetAccAddr(ctx.BlockHeader().ProposerAddress, proposerAddr)
+	require.Nil(t, am.GetAccount(ctx, proposerAddr))
+
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForProposer))
+	// the account used to be required before the upgrade
+	cacheCtx, _ := ctx.CacheContext()
+	require.Panics(t, func() { distributeFee(cacheCtx, am, valAddrCache, true) })
+
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, math.MaxInt64)
+	blockFee := distributeFee(ctx, am, valAddrCache, true)
+	fees.Pool.Clear()
+	require.Equal(t, pub.BlockFee{0, "BNB:10", []string{string(proposerAddr)}}, blockFee)
+	checkBalance(t, ctx, am, valAddrCache, []int64{10, 100, 100, 100})
+}
+
 func TestFeeDistribution2AllValidators(t *testing.T) {
 	// setup
 	am, valAddrCache, ctx, proposerAcc, valAcc1, valAcc2, valAcc3 := setup()
@@ -119,6 +157,372 @@ func TestFeeDistribution2AllValidators(t *testing.T) {
 	checkBalance(t, ctx, am, valAddrCache, []int64{124, 122, 122, 122})
 }
 
+func TestFeeDistribution2AllValidatorsRoundingRemainder(t *testing.T) {
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, math.MaxInt64)
+	proposer := sdk.AccAddress([]byte("proposer"))
+	val1 := sdk.AccAddress([]byte("val1"))
+	val2 := sdk.AccAddress([]byte("val2"))
+	val3 := sdk.AccAddress([]byte("val3"))
+	fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 31)}, sdk.FeeForAll)
+	expected := map[string]sdk.Coins{
+		string(val1): {sdk.NewCoin(types.NativeTokenSymbol, 11)},
+		string(val2): {sdk.NewCoin(types.NativeTokenSymbol, 10)},
+		string(val3): {sdk.NewCoin(types.NativeTokenSymbol, 10)},
+	}
+
+	// the proposer didn't vote, the remainder goes to the lowest address whatever the order is
+	for _, validators := range [][]sdk.AccAddress{
+		{val1, val2, val3},
+		{val3, val1, val2},
+		{val2, val3, val1},
+	} {
+		shares := allValidatorsFeeDistributor{}.Distribute(sdk.Context{}, fee, validators, proposer)
+		require.Equal(t, expected, sharesByAddr(shares))
+	}
+
+	// the proposer still takes the remainder if it's among the validators, and its share comes first
+	shares := allValidatorsFeeDistributor{}.Distribute(sdk.Context{}, fee, []sdk.AccAddress{val2, val3}, val3)
+	require.Equal(t, []FeeShare{
+		{Addr: val3, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 16)}},
+		{Addr: val2, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 15)}},
+	}, shares)
+}
+
+func TestFeeDistribution2AllValidatorsRoundingRemainderBeforeUpgrade(t *testing.T) {
+	proposer := sdk.AccAddress([]byte("proposer"))
+	val1 := sdk.AccAddress([]byte("val1"))
+	val2 := sdk.AccAddress([]byte("val2"))
+	fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 31)}, sdk.FeeForAll)
+
+	// the remainder is dropped if the proposer didn't vote, as it used to be
+	shares := allValidatorsFeeDistributor{}.Distribute(sdk.Context{}, fee, []sdk.AccAddress{val1, val2}, proposer)
+	require.Equal(t, []FeeShare{
+		{Addr: val1, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 15)}},
+		{Addr: val2, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 15)}},
+	}, shares)
+}
+
+func sharesByAddr(shares []FeeShare) map[string]sdk.Coins {
+	byAddr := make(map[string]sdk.Coins, len(shares))
+	for _, share := range shares {
+		byAddr[string(share.Addr)] = byAddr[string(share.Addr)].Plus(share.Tokens)
+	}
+	return byAddr
+}
+
+func TestFeeDistribution2AllValidatorsSharedAccount(t *testing.T) {
+	am, valAddrCache, ctx, proposerAcc, valAcc1, _, valAcc3 := setup()
+	// the last two validators share the same fee address
+	voteInfos := ctx.VoteInfos()
+	valAddrCache.SetAccAddr(voteInfos[2].Validator.Address, valAcc3.GetAddress())
+
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}, sdk.FeeForAll))
+	blockFee := distributeFee(ctx, am, valAddrCache, true)
+	fees.Pool.Clear()
+	require.Equal(t, pub.BlockFee{0, "BNB:40", []string{string(proposerAcc.GetAddress()), string(valAcc1.GetAddress()), string(valAcc3.GetAddress()), string(valAcc3.GetAddress())}}, blockFee)
+	checkBalance(t, ctx, am, valAddrCache, []int64{110, 110, 120, 120})
+}
+
+func TestFeeDistribution2AllValidatorsProposerNotVoting(t *testing.T) {
+	am, valAddrCache, ctx, proposerAcc, valAcc1, valAcc2, valAcc3 := setup()
+	ctx = ctx.WithVoteInfos(ctx.VoteInfos()[1:])
+
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 30)}, sdk.FeeForAll))
+	blockFee := distributeFee(ctx, am, valAddrCache, true)
+	fees.Pool.Clear()
+	// the proposer is still published first
+	require.Equal(t, pub.BlockFee{0, "BNB:30", []string{string(proposerAcc.GetAddress()), string(valAcc1.GetAddress()), string(valAcc2.GetAddress()), string(valAcc3.GetAddress())}}, blockFee)
+	checkBalance(t, ctx, am, valAddrCache, []int64{110, 110, 110})
+	require.Equal(t, int64(100), am.GetAccount(ctx, proposerAcc.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+}
+
+func TestFeeDistribution2AllValidatorsMultiDenom(t *testing.T) {
+	proposer := sdk.AccAddress([]byte("proposer"))
+	val1 := sdk.AccAddress([]byte("val1"))
+	val2 := sdk.AccAddress([]byte("val2"))
+	// both denoms are split unevenly, each remainder goes to the proposer
+	fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 50), sdk.NewCoin("XYZ-000", 7)}, sdk.FeeForAll)
+	shares := allValidatorsFeeDistributor{}.Distribute(sdk.Context{}, fee, []sdk.AccAddress{proposer, val1, val2}, proposer)
+	require.Equal(t, []FeeShare{
+		{Addr: proposer, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 18), sdk.NewCoin("XYZ-000", 3)}},
+		{Addr: val1, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 16), sdk.NewCoin("XYZ-000", 2)}},
+		{Addr: val2, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 16), sdk.NewCoin("XYZ-000", 2)}},
+	}, shares)
+}
+
+func TestFeeDistributionBEP159(t *testing.T) {
+	_, _, ctx, _, _, _, _ := setup()
+	proposer := sdk.AccAddress([]byte("proposer"))
+	feeForAll := sdk.AccAddress([]byte("feeForAll"))
+	distributor := bep159FeeDistributor{
+		baseProposerRewardRatio:  sdk.NewDecWithPrec(1, 2),
+		bonusProposerRewardRatio: sdk.NewDecWithPrec(4, 2),
+	}
+	fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1000)}, sdk.FeeForAll)
+	shares := distributor.Distribute(ctx, fee, []sdk.AccAddress{feeForAll}, proposer)
+	require.Equal(t, []FeeShare{
+		{Addr: feeForAll, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 950)}},
+		{Addr: proposer, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 50)}},
+	}, shares)
+
+	// the bonus is proportional to the signing validators
+	voteInfos := ctx.VoteInfos()
+	voteInfos[3].SignedLastBlock = false
+	shares = distributor.Distribute(ctx.WithVoteInfos(voteInfos), fee, []sdk.AccAddress{feeForAll}, proposer)
+	require.Equal(t, []FeeShare{
+		{Addr: feeForAll, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 960)}},
+		{Addr: proposer, Tokens: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}},
+	}, shares)
+}
+
+func TestFeeDistributionBEP159RoundingRemainder(t *testing.T) {
+	_, _, ctx, _, _, _, _ := setup()
+	proposer := sdk.AccAddress([]byte("proposer"))
+	feeForAll := sdk.AccAddress([]byte("feeForAll"))
+	distributor := bep159FeeDistributor{
+		baseProposerRewardRatio:  sdk.NewDecWithPrec(1, 2),
+		bonusProposerRewardRatio: sdk.NewDecWithPrec(4, 2),
+	}
+	voteInfos := ctx.VoteInfos()
+	voteInfos[3].SignedLastBlock = false
+	ctx = ctx.WithVoteInfos(voteInfos)
+
+	// the rewards can't be split evenly, the fee-for-all account takes what the rounded proposer reward leaves
+	for _, amount := range []int64{1, 7, 1013, 99999} {
+		fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, amount)}, sdk.FeeForAll)
+		shares := distributor.Distribute(ctx, fee, []sdk.AccAddress{feeForAll}, proposer)
+		require.NotPanics(t, func() { mustDistributeAll(fee, shares) })
+	}
+}
+
+func setupBEP159(t *testing.T) (sdk.Context, auth.AccountKeeper, stake.Keeper) {
+	ctx, am, stakeKeeper := stakekeeper.CreateTestInput(t, false, 100)
+	return ctx, am, stakeKeeper
+}
+
+func TestFeeDistributionBEP159WithoutProposer(t *testing.T) {
+	ctx, am, stakeKeeper := setupBEP159(t)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, math.MaxInt64)
+
+	// the fee of the previous block, whose proposer had no distribution address
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1000)}
+	_, _, err := stakeKeeper.BankKeeper.AddCoins(ctx, stake.FeeCollectorAddr, fee)
+	require.NoError(t, err)
+	require.Empty(t, stakeKeeper.GetPrevProposerDistributionAddr(ctx))
+
+	distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper)
+	feeForAll := stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeForAllAccAddr)
+	require.False(t, feeForAll.IsZero())
+	// the proposer's share stays in the collector instead of going to an account with an empty address
+	require.Nil(t, am.GetAccount(ctx, sdk.AccAddress{}))
+	require.Equal(t, fee, feeForAll.Plus(stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeCollectorAddr)))
+}
+
+type burnHalfFeeDistributor struct {
+	called bool
+}
+
+func (d *burnHalfFeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
+	d.called = true
+	half := sdk.Coins{}
+	for _, token := range fee.Tokens {
+		half = append(half, sdk.NewCoin(token.Denom, token.Amount/2))
+	}
+	return []FeeShare{{Addr: validators[len(validators)-1], Tokens: half}}
+}
+
+func TestFeeDistributionCustomDistributor(t *testing.T) {
+	const feeForLastValidator = sdk.FeeDistributeType(0x10)
+	distributor := &burnHalfFeeDistributor{}
+	RegisterFeeDistributor(feeForLastValidator, distributor)
+	defer delete(feeDistributors, feeForLastValidator)
+
+	am, valAddrCache, ctx, proposerAcc, _, _, valAcc3 := setup()
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}, feeForLastValidator))
+	blockFee := distributeFee(ctx, am, valAddrCache, true)
+	fees.Pool.Clear()
+	require.True(t, distributor.called)
+	require.Equal(t, pub.BlockFee{0, "BNB:40", []string{string(proposerAcc.GetAddress()), string(valAcc3.GetAddress())}}, blockFee)
+	checkBalance(t, ctx, am, valAddrCache, []int64{100, 100, 100, 120})
+}
+
+type doubleFeeDistributor struct{}
+
+func (doubleFeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
+	return []FeeShare{{Addr: proposer, Tokens: fee.Tokens.Plus(fee.Tokens)}}
+}
+
+func TestFeeDistributionCheck(t *testing.T) {
+	SetFeeDistributionCheck(true)
+	defer SetFeeDistributionCheck(false)
+
+	// the built-in distribution passes
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 50)}, sdk.FeeForAll))
+	require.NotPanics(t, func() { distributeFee(ctx, am, valAddrCache, false) })
+	fees.Pool.Clear()
+	checkBalance(t, ctx, am, valAddrCache, []int64{114, 112, 112, 112})
+
+	// a broken distributor triggers the assertion
+	const feeDoubled = sdk.FeeDistributeType(0x12)
+	RegisterFeeDistributor(feeDoubled, doubleFeeDistributor{})
+	defer delete(feeDistributors, feeDoubled)
+	fees.Pool.AddAndCommitFee("DIST", sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, feeDoubled))
+	require.Panics(t, func() { distributeFee(ctx, am, valAddrCache, false) })
+	fees.Pool.Clear()
+}
+
+func TestFeeDistributionCheckBEP159(t *testing.T) {
+	SetFeeDistributionCheck(true)
+	defer SetFeeDistributionCheck(false)
+	ctx, am, stakeKeeper := setupBEP159(t)
+	stakeKeeper.SetPrevProposerDistributionAddr(ctx, sdk.AccAddress([]byte("proposer")))
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1000)}
+
+	// the built-in distribution passes
+	_, _, err := stakeKeeper.BankKeeper.AddCoins(ctx, stake.FeeCollectorAddr, fee)
+	require.NoError(t, err)
+	require.NotPanics(t, func() { distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper) })
+	require.True(t, stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeCollectorAddr).IsZero())
+
+	// a distributor leaving a part of the fee triggers the assertion
+	SetBEP159FeeDistributor(&burnHalfFeeDistributor{})
+	defer SetBEP159FeeDistributor(nil)
+	_, _, err = stakeKeeper.BankKeeper.AddCoins(ctx, stake.FeeCollectorAddr, fee)
+	require.NoError(t, err)
+	require.Panics(t, func() { distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper) })
+}
+
+type noEligibleFeeDistributor struct{}
+
+func (noEligibleFeeDistributor) Distribute(ctx sdk.Context, fee sdk.Fee, validators []sdk.AccAddress, proposer sdk.AccAddress) []FeeShare {
+	return nil
+}
+
+func TestFeeDistributionRefundUndistributableFee(t *testing.T) {
+	const feeForNobody = sdk.FeeDistributeType(0x11)
+	RegisterFeeDistributor(feeForNobody, noEligibleFeeDistributor{})
+	defer delete(feeDistributors, feeForNobody)
+
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	_, payerAcc := testutils.NewAccount(ctx, am, 60)
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}
+	tx.FeePayers.AddPayment("TX1", payerAcc.GetAddress(), fee)
+	tx.FeePayers.Commit("TX1")
+	fees.Pool.AddAndCommitFee("TX1", sdk.NewFee(fee, feeForNobody))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	tx.FeePayers.Clear()
+	require.Equal(t, int64(100), am.GetAccount(ctx, payerAcc.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+	checkBalance(t, ctx, am, valAddrCache, []int64{100, 100, 100, 100})
+
+	// route to the sink instead of refunding
+	_, sinkAcc := testutils.NewAccount(ctx, am, 0)
+	SetUndistributableFeeSink(sinkAcc.GetAddress())
+	defer SetUndistributableFeeSink(nil)
+	tx.FeePayers.AddPayment("TX2", payerAcc.GetAddress(), fee)
+	tx.FeePayers.Commit("TX2")
+	fees.Pool.AddAndCommitFee("TX2", sdk.NewFee(fee, feeForNobody))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	tx.FeePayers.Clear()
+	require.Equal(t, int64(100), am.GetAccount(ctx, payerAcc.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+	require.Equal(t, int64(40), am.GetAccount(ctx, sinkAcc.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+}
+
+func TestFeeDistributionRefundNoSigningValidator(t *testing.T) {
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, math.MaxInt64)
+
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	// none of the validators signed the last block
+	voteInfos := ctx.VoteInfos()
+	for i := range voteInfos {
+		voteInfos[i].SignedLastBlock = false
+	}
+	ctx = ctx.WithVoteInfos(voteInfos)
+	_, payerAcc := testutils.NewAccount(ctx, am, 60)
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}
+	tx.FeePayers.AddPayment("TX1", payerAcc.GetAddress(), fee)
+	tx.FeePayers.Commit("TX1")
+	fees.Pool.AddAndCommitFee("TX1", sdk.NewFee(fee, sdk.FeeForAll))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	tx.FeePayers.Clear()
+	require.Equal(t, int64(100), am.GetAccount(ctx, payerAcc.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+	checkBalance(t, ctx, am, valAddrCache, []int64{100, 100, 100, 100})
+
+	// the validators which signed still share it
+	voteInfos[1].SignedLastBlock = true
+	voteInfos[2].SignedLastBlock = true
+	ctx = ctx.WithVoteInfos(voteInfos)
+	fees.Pool.AddAndCommitFee("TX2", sdk.NewFee(fee, sdk.FeeForAll))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	checkBalance(t, ctx, am, valAddrCache, []int64{100, 120, 120, 100})
+}
+
+func TestFeeDistributionBEP159UndistributableFee(t *testing.T) {
+	ctx, am, stakeKeeper := setupBEP159(t)
+	upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.FixFeeDistribution, math.MaxInt64)
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1000)}
+
+	// nobody is eligible, the fee stays in the collector for the next block
+	SetBEP159FeeDistributor(noEligibleFeeDistributor{})
+	defer SetBEP159FeeDistributor(nil)
+	_, _, err := stakeKeeper.BankKeeper.AddCoins(ctx, stake.FeeCollectorAddr, fee)
+	require.NoError(t, err)
+	distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper)
+	require.Equal(t, fee, stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeCollectorAddr))
+
+	// or goes to the sink
+	sink := sdk.AccAddress([]byte("sink"))
+	SetUndistributableFeeSink(sink)
+	defer SetUndistributableFeeSink(nil)
+	distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper)
+	require.True(t, stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeCollectorAddr).IsZero())
+	require.Equal(t, fee, stakeKeeper.BankKeeper.GetCoins(ctx, sink))
+
+	// the share of a previous proposer without distribution address goes to the sink too
+	SetBEP159FeeDistributor(nil)
+	_, _, err = stakeKeeper.BankKeeper.AddCoins(ctx, stake.FeeCollectorAddr, fee)
+	require.NoError(t, err)
+	distributeFeeBEP159(ctx, am, NewValAddrCache(stakeKeeper), false, stakeKeeper)
+	require.True(t, stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeCollectorAddr).IsZero())
+	feeForAll := stakeKeeper.BankKeeper.GetCoins(ctx, stake.FeeForAllAccAddr)
+	require.False(t, feeForAll.IsZero())
+	require.Equal(t, fee.Plus(fee), feeForAll.Plus(stakeKeeper.BankKeeper.GetCoins(ctx, sink)))
+}
+
+func TestFeeDistributionRotateFeeSink(t *testing.T) {
+	const feeForNobody = sdk.FeeDistributeType(0x11)
+	RegisterFeeDistributor(feeForNobody, noEligibleFeeDistributor{})
+	defer delete(feeDistributors, feeForNobody)
+	defer SetUndistributableFeeSink(nil)
+
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	_, sinkAcc1 := testutils.NewAccount(ctx, am, 0)
+	_, sinkAcc2 := testutils.NewAccount(ctx, am, 0)
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}
+
+	SetUndistributableFeeSink(sinkAcc1.GetAddress())
+	fees.Pool.AddAndCommitFee("TX1", sdk.NewFee(fee, feeForNobody))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+
+	// the following fees go to the new sink, the old one keeps what it got
+	SetUndistributableFeeSink(sinkAcc2.GetAddress())
+	fees.Pool.AddAndCommitFee("TX2", sdk.NewFee(fee, feeForNobody))
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	require.Equal(t, int64(40), am.GetAccount(ctx, sinkAcc1.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+	require.Equal(t, int64(40), am.GetAccount(ctx, sinkAcc2.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+	checkBalance(t, ctx, am, valAddrCache, []int64{100, 100, 100, 100})
+}
+
 type Account struct {
 	Priv           crypto.PrivKey
 	CryptoAddress  crypto.Address
@@ -1,9 +1,11 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/server"
@@ -75,6 +77,25 @@ func (ga *GenesisAccount) ToAppAccount() (acc *types.AppAccount) {
 	}
 }
 
+// CreateGenesisAccounts creates the accounts sorted by address with sequential account numbers,
+// so that the same set of accounts gets the same numbers whatever order they are imported in.
+func CreateGenesisAccounts(ctx sdk.Context, am auth.AccountKeeper, accs []GenesisAccount) []*types.AppAccount {
+	sorted := make([]GenesisAccount, len(accs))
+	copy(sorted, accs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Address, sorted[j].Address) < 0
+	})
+
+	created := make([]*types.AppAccount, 0, len(sorted))
+	for _, gacc := range sorted {
+		acc := gacc.ToAppAccount()
+		acc.AccountNumber = am.GetNextAccountNumber(ctx)
+		am.SetAccount(ctx, acc)
+		created = append(created, acc)
+	}
+	return created
+}
+
 func BNBAppInit() server.AppInit {
 	return server.AppInit{
 		AppGenState: BNBAppGenState,
@@ -109,7 +130,7 @@ func BNBAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appState json
 			// add validator self-delegation account first
 			if !msg.DelegatorAddr.Equals(operAddr) {
 				delAcc := types.AppAccount{BaseAccount: auth.NewBaseAccountWithAddress(msg.DelegatorAddr)}
-				if len(msg.Description.Moniker) > 0 {
+				if types.ValidateAccountName(msg.Description.Moniker) == nil {
 					delAcc.SetName(msg.Description.Moniker)
 				}
 				genAccounts = append(genAccounts, NewGenesisAccount(&delAcc, nil))
@@ -117,7 +138,8 @@ func BNBAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (appState json
 
 			// add validator operator account
 			operAcc := types.AppAccount{BaseAccount: auth.NewBaseAccountWithAddress(operAddr)}
-			if len(msg.Description.Moniker) > 0 {
+			// the monikers which are not valid account names are left out, so the accounts can sign txs
+			if types.ValidateAccountName(msg.Description.Moniker) == nil {
 				operAcc.SetName(msg.Description.Moniker)
 			}
 			genAccounts = append(genAccounts, NewGenesisAccount(&operAcc, msg.PubKey.Address()))
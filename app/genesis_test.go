@@ -0,0 +1,56 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestCreateGenesisAccountsDeterministicNumbers(t *testing.T) {
+	var accs []GenesisAccount
+	for i := 0; i < 5; i++ {
+		_, addr := testutils.PrivAndAddr()
+		accs = append(accs, GenesisAccount{Name: addr.String(), Address: addr})
+	}
+	reversed := make([]GenesisAccount, len(accs))
+	for i, acc := range accs {
+		reversed[len(accs)-1-i] = acc
+	}
+
+	importAccounts := func(accs []GenesisAccount) map[string]int64 {
+		ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+		cdc := wire.NewCodec()
+		types.RegisterWire(cdc)
+		am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+		accountCache := getAccountCache(cdc, ms, capKey)
+		ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+		CreateGenesisAccounts(ctx, am, accs)
+		numbers := make(map[string]int64)
+		for _, acc := range accs {
+			numbers[acc.Address.String()] = am.GetAccount(ctx, acc.Address).GetAccountNumber()
+		}
+		return numbers
+	}
+
+	numbers := importAccounts(accs)
+	require.Equal(t, numbers, importAccounts(reversed))
+
+	// the numbers are gap-free
+	seen := make(map[int64]bool)
+	for _, number := range numbers {
+		seen[number] = true
+	}
+	for i := int64(0); i < int64(len(accs)); i++ {
+		require.True(t, seen[i])
+	}
+}
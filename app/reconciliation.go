@@ -60,6 +60,7 @@ func (app *BNBBeaconChain) getAccountChanges(ctx sdk.Context, accountStore *stor
 				currentCoins = currentCoins.Plus(nacc1.GetCoins())
 				currentCoins = currentCoins.Plus(nacc1.GetFrozenCoins())
 				currentCoins = currentCoins.Plus(nacc1.GetLockedCoins())
+				currentCoins = currentCoins.Plus(nacc1.GetVestingCoins())
 			}
 		}
 
@@ -75,6 +76,7 @@ func (app *BNBBeaconChain) getAccountChanges(ctx sdk.Context, accountStore *stor
 				preCoins = preCoins.Plus(nacc2.GetCoins())
 				preCoins = preCoins.Plus(nacc2.GetFrozenCoins())
 				preCoins = preCoins.Plus(nacc2.GetLockedCoins())
+				preCoins = preCoins.Plus(nacc2.GetVestingCoins())
 			}
 		}
 	}
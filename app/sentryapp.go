@@ -100,8 +100,8 @@ func (app *SentryApplication) ReCheckTx(req abci.RequestCheckTx) (res abci.Respo
 	// Decode the Tx.
 	txHash := common.HexBytes(tmhash.Sum(req.Tx)).String()
 
-	if tx := app.cache.get(txHash); tx != nil {
-		if tx.survive >= SentryAppConfig.MaxSurvive {
+	if cachedTx := app.cache.get(txHash); cachedTx != nil {
+		if cachedTx.survive >= SentryAppConfig.MaxSurvive {
 			app.cache.delete(txHash)
 			app.logger.Info("Remove tx", "txHash", txHash)
 			return abci.ResponseCheckTx{
@@ -110,7 +110,7 @@ func (app *SentryApplication) ReCheckTx(req abci.RequestCheckTx) (res abci.Respo
 			}
 		} else {
 			// Mark as good
-			tx.rechecked = true
+			cachedTx.rechecked = true
 		}
 	} else {
 		if app.cache.size() > SentryAppConfig.CacheSize {
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// CheckSupplyInvariant checks the total supply of the tokens equals the sum of the free, locked and frozen coins
+// of all the accounts plus the fees collected in the block but not distributed yet.
+// The accounts are read from the store, so the account cache has to be written before, e.g. at the end of a block.
+func (app *BNBBeaconChain) CheckSupplyInvariant(ctx sdk.Context) error {
+	totalSupply := sdk.Coins{}
+	for _, isMini := range []bool{false, true} {
+		for _, token := range app.TokenMapper.GetTokenList(ctx, false, isMini) {
+			totalSupply = totalSupply.Plus(sdk.Coins{sdk.NewCoin(token.GetSymbol(), token.GetTotalSupply().ToInt64())})
+		}
+	}
+	return checkSupplyInvariant(ctx, app.AccountKeeper, totalSupply)
+}
+
+func checkSupplyInvariant(ctx sdk.Context, am auth.AccountKeeper, totalSupply sdk.Coins) error {
+	sum := fees.Pool.BlockFees().Tokens.Sort()
+	am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+		sum = sum.Plus(acc.GetCoins())
+		if namedAcc, ok := acc.(types.NamedAccount); ok {
+			sum = sum.Plus(namedAcc.GetLockedCoins()).Plus(namedAcc.GetFrozenCoins()).Plus(namedAcc.GetVestingCoins())
+		}
+		return false
+	})
+
+	if !sum.IsEqual(totalSupply.Sort()) {
+		return fmt.Errorf("supply invariant broken at height %d, total supply: %s, sum of accounts and fee pool: %s",
+			ctx.BlockHeight(), totalSupply, sum)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestSupplyInvariantWithFeeDistribution(t *testing.T) {
+	am, valAddrCache, ctx, _, _, _, _ := setup()
+	_, payer := testutils.NewAccount(ctx, am, 100)
+	totalSupply := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 500)}
+
+	ctx.AccountCache().Write()
+	require.NoError(t, checkSupplyInvariant(ctx, am, totalSupply))
+
+	// the fee is deducted from the payer and kept in the pool until the end of the block
+	fee := sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 40)}, sdk.FeeForAll)
+	require.NoError(t, payer.SetCoins(payer.GetCoins().Minus(fee.Tokens)))
+	am.SetAccount(ctx, payer)
+	fees.Pool.AddAndCommitFee("DIST", fee)
+	ctx.AccountCache().Write()
+	require.NoError(t, checkSupplyInvariant(ctx, am, totalSupply))
+
+	distributeFee(ctx, am, valAddrCache, false)
+	fees.Pool.Clear()
+	ctx.AccountCache().Write()
+	require.NoError(t, checkSupplyInvariant(ctx, am, totalSupply))
+	checkBalance(t, ctx, am, valAddrCache, []int64{110, 110, 110, 110})
+
+	// coins out of thin air break the invariant
+	_, _ = testutils.NewAccount(ctx, am, 1)
+	ctx.AccountCache().Write()
+	require.Error(t, checkSupplyInvariant(ctx, am, totalSupply))
+}
@@ -21,6 +21,7 @@ const (
 	IbcStoreName         = "ibc"
 	SideChainStoreName   = "sc"
 	ReconStoreName       = "recon"
+	FeeConsentStoreName  = "fee_consent"
 
 	StakeTransientStoreName  = "transient_stake"
 	ParamsTransientStoreName = "transient_params"
@@ -46,6 +47,7 @@ var (
 	IbcStoreKey         = sdk.NewKVStoreKey(IbcStoreName)
 	SideChainStoreKey   = sdk.NewKVStoreKey(SideChainStoreName)
 	ReconStoreKey       = sdk.NewKVStoreKey(ReconStoreName)
+	FeeConsentStoreKey  = sdk.NewKVStoreKey(FeeConsentStoreName)
 
 	TStakeStoreKey  = sdk.NewTransientStoreKey(StakeTransientStoreName)
 	TParamsStoreKey = sdk.NewTransientStoreKey(ParamsTransientStoreName)
@@ -69,6 +71,7 @@ var (
 		BridgeStoreName:          BridgeStoreKey,
 		OracleStoreName:          OracleStoreKey,
 		ReconStoreName:           ReconStoreKey,
+		FeeConsentStoreName:      FeeConsentStoreKey,
 		StakeTransientStoreName:  TStakeStoreKey,
 		ParamsTransientStoreName: TParamsStoreKey,
 	}
@@ -92,6 +95,7 @@ var (
 		BridgeStoreName,
 		OracleStoreName,
 		ReconStoreName,
+		FeeConsentStoreName,
 	}
 )
 
@@ -56,7 +56,9 @@ func PrivAndAddr() (crypto.PrivKey, sdk.AccAddress) {
 func NewAccount(ctx sdk.Context, am auth.AccountKeeper, free int64) (crypto.PrivKey, sdk.Account) {
 	privKey, addr := PrivAndAddr()
 	acc := am.NewAccountWithAddress(ctx, addr)
-	_ = acc.SetCoins(NewNativeTokens(free))
+	if err := acc.SetCoins(NewNativeTokens(free)); err != nil {
+		panic(err)
+	}
 	am.SetAccount(ctx, acc)
 	return privKey, acc
 }
@@ -64,7 +66,9 @@ func NewAccount(ctx sdk.Context, am auth.AccountKeeper, free int64) (crypto.Priv
 func NewNamedAccount(ctx sdk.Context, am auth.AccountKeeper, free int64) (crypto.PrivKey, types.NamedAccount) {
 	privKey, addr := PrivAndAddr()
 	acc := am.NewAccountWithAddress(ctx, addr)
-	_ = acc.SetCoins(NewNativeTokens(free))
+	if err := acc.SetCoins(NewNativeTokens(free)); err != nil {
+		panic(err)
+	}
 
 	baseAcc := auth.BaseAccount{
 		Address:       acc.GetAddress(),
@@ -87,7 +91,9 @@ func NewAccountForPub(ctx sdk.Context, am auth.AccountKeeper, free, locked, free
 	acc := am.NewAccountWithAddress(ctx, addr)
 	coins := NewNativeTokens(free)
 	coins = append(coins, sdk.NewCoin(symbol, free))
-	_ = acc.SetCoins(coins)
+	if err := acc.SetCoins(coins); err != nil {
+		panic(err)
+	}
 
 	appAcc := acc.(*types.AppAccount)
 	lockedCoins := NewNativeTokens(locked)
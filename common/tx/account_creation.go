@@ -0,0 +1,57 @@
+package tx
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var accountCreationKeyPrefix = []byte("accCreated:")
+
+// AccountCreationKeeper indexes the accounts by the height they are created at, i.e. when their pubkeys are set.
+type AccountCreationKeeper struct {
+	key sdk.StoreKey
+}
+
+func NewAccountCreationKeeper(key sdk.StoreKey) AccountCreationKeeper {
+	return AccountCreationKeeper{key: key}
+}
+
+func accountCreationHeightKey(height int64) []byte {
+	key := make([]byte, len(accountCreationKeyPrefix)+8)
+	copy(key, accountCreationKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(accountCreationKeyPrefix):], uint64(height))
+	return key
+}
+
+func (k AccountCreationKeeper) addCreatedAccount(ctx sdk.Context, addr sdk.AccAddress) {
+	key := append(accountCreationHeightKey(ctx.BlockHeight()), addr.Bytes()...)
+	ctx.KVStore(k.key).Set(key, []byte{})
+}
+
+// AccountsCreatedBetween returns the accounts created in [startHeight, endHeight], ordered by the height
+func (k AccountCreationKeeper) AccountsCreatedBetween(ctx sdk.Context, startHeight, endHeight int64) []sdk.AccAddress {
+	if startHeight < 0 {
+		startHeight = 0
+	}
+	if endHeight < startHeight {
+		return nil
+	}
+
+	iter := ctx.KVStore(k.key).Iterator(accountCreationHeightKey(startHeight), accountCreationHeightKey(endHeight+1))
+	defer iter.Close()
+
+	var addrs []sdk.AccAddress
+	for ; iter.Valid(); iter.Next() {
+		addr := iter.Key()[len(accountCreationKeyPrefix)+8:]
+		addrs = append(addrs, append(sdk.AccAddress{}, addr...))
+	}
+	return addrs
+}
+
+// the keeper used by the ante handler to index the new accounts, nil means indexing is disabled
+var accountCreationKeeper *AccountCreationKeeper
+
+func SetAccountCreationKeeper(k *AccountCreationKeeper) {
+	accountCreationKeeper = k
+}
@@ -0,0 +1,52 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAccountsCreatedBetween(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	creationKeeper := tx.NewAccountCreationKeeper(capKey2)
+	tx.SetAccountCreationKeeper(&creationKeeper)
+	defer tx.SetAccountCreationKeeper(nil)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	// the accounts are created when they send their first txs at the heights
+	var privs []crypto.PrivKey
+	var addrs []sdk.AccAddress
+	for i, height := range []int64{5, 10, 10, 15, 20} {
+		priv, acc := testutils.NewAccount(ctx, am, 100)
+		privs = append(privs, priv)
+		addrs = append(addrs, acc.GetAddress())
+		txn := newTestTx(ctx, []sdk.Msg{newTestMsg(acc.GetAddress())}, []crypto.PrivKey{priv}, []int64{int64(i)}, []int64{0})
+		checkValidTx(t, anteHandler, ctx.WithBlockHeight(height), txn, sdk.RunTxModeDeliver)
+	}
+
+	// the later txs don't index the account again
+	txn := newTestTx(ctx, []sdk.Msg{newTestMsg(addrs[0])}, []crypto.PrivKey{privs[0]}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx.WithBlockHeight(30), txn, sdk.RunTxModeDeliver)
+	require.Len(t, creationKeeper.AccountsCreatedBetween(ctx, 0, 100), 5)
+
+	require.ElementsMatch(t, addrs[1:4], creationKeeper.AccountsCreatedBetween(ctx, 10, 15))
+	require.Equal(t, addrs[4:], creationKeeper.AccountsCreatedBetween(ctx, 16, 20))
+	require.Empty(t, creationKeeper.AccountsCreatedBetween(ctx, 6, 9))
+	require.Empty(t, creationKeeper.AccountsCreatedBetween(ctx, 21, 100))
+}
@@ -0,0 +1,144 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/bnb-chain/node/wire"
+)
+
+var accountCountKey = []byte("accCount")
+
+// AccountCounter keeps the number of accounts created so far. The account numbers can't be used for it, as
+// they may start from an offset, see types.SetAccountNumberOffset.
+type AccountCounter struct {
+	cdc *wire.Codec
+	key sdk.StoreKey
+}
+
+func NewAccountCounter(cdc *wire.Codec, key sdk.StoreKey) AccountCounter {
+	return AccountCounter{cdc: cdc, key: key}
+}
+
+func (c AccountCounter) get(ctx sdk.Context) int64 {
+	var count int64
+	if bz := ctx.KVStore(c.key).Get(accountCountKey); bz != nil {
+		c.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &count)
+	}
+	return count
+}
+
+func (c AccountCounter) set(ctx sdk.Context, count int64) {
+	ctx.KVStore(c.key).Set(accountCountKey, c.cdc.MustMarshalBinaryLengthPrefixed(count))
+}
+
+// InitAccountCounter sets the counter to the number of the accounts in the store, e.g. when the counter is enabled
+// on a running chain. The accounts still in the account cache of the ctx are not counted.
+func (c AccountCounter) InitAccountCounter(ctx sdk.Context, am auth.AccountKeeper) {
+	var count int64
+	am.IterateAccounts(ctx, func(sdk.Account) bool {
+		count++
+		return false
+	})
+	c.set(ctx, count)
+}
+
+// the counter of the accounts created by the msgs, nil means the accounts are not counted
+var accountCounter *AccountCounter
+
+func SetAccountCounter(c *AccountCounter) {
+	accountCounter = c
+}
+
+// TotalAccounts returns the number of accounts created so far, 0 if the accounts are not counted
+func TotalAccounts(ctx sdk.Context) int64 {
+	if accountCounter == nil {
+		return 0
+	}
+	return accountCounter.get(ctx)
+}
+
+// checkMaxAccounts rejects the transfers to new accounts beyond the limit before the msgs are run,
+// the msgs creating accounts in other ways are checked once they are run by the router of NewAccountLimitRouter.
+func checkMaxAccounts(ctx sdk.Context, am auth.AccountKeeper, msgs []sdk.Msg, maxAccounts int64) sdk.Error {
+	if maxAccounts <= 0 || accountCounter == nil {
+		return nil
+	}
+
+	newAccounts := make(map[string]bool)
+	for _, msg := range msgs {
+		send, ok := msg.(bank.MsgSend)
+		if !ok {
+			continue
+		}
+		for _, output := range send.Outputs {
+			if am.GetAccount(ctx, output.Address) == nil {
+				newAccounts[string(output.Address)] = true
+			}
+		}
+	}
+
+	if len(newAccounts) == 0 {
+		return nil
+	}
+	return checkAccountsCreated(TotalAccounts(ctx), int64(len(newAccounts)), maxAccounts)
+}
+
+func checkAccountsCreated(total, created, maxAccounts int64) sdk.Error {
+	if maxAccounts > 0 && total+created > maxAccounts {
+		return ErrTooManyAccounts(fmt.Sprintf("cannot create %d new accounts, there are already %d accounts and the limit is %d",
+			created, total, maxAccounts))
+	}
+	return nil
+}
+
+type accountLimitRouter struct {
+	baseapp.Router
+	am          auth.AccountKeeper
+	maxAccounts int64
+}
+
+// NewAccountLimitRouter wraps the router so that the accounts created by the msg handlers added through it
+// are counted by the counter set by SetAccountCounter, and the msgs creating accounts beyond the MaxAccounts
+// of the options fail.
+func NewAccountLimitRouter(router baseapp.Router, am auth.AccountKeeper, opts AnteOptions) baseapp.Router {
+	return accountLimitRouter{Router: router, am: am, maxAccounts: opts.MaxAccounts}
+}
+
+func (r accountLimitRouter) AddRoute(path string, handler sdk.Handler) baseapp.Router {
+	r.Router.AddRoute(path, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if accountCounter == nil {
+			return handler(ctx, msg)
+		}
+
+		before := r.nextAccountNumber(ctx)
+		res := handler(ctx, msg)
+		if !res.IsOK() {
+			return res
+		}
+		// an account number is assigned to every new account, and only to them
+		created := r.nextAccountNumber(ctx) - before
+		if created == 0 {
+			return res
+		}
+		total := accountCounter.get(ctx)
+		// the state of the msg is not written if it fails
+		if err := checkAccountsCreated(total, created, r.maxAccounts); err != nil {
+			return err.Result()
+		}
+		accountCounter.set(ctx, total+created)
+		return res
+	})
+	return r
+}
+
+// nextAccountNumber reads the next account number from a discarded cache context,
+// because GetNextAccountNumber also increments it.
+func (r accountLimitRouter) nextAccountNumber(ctx sdk.Context) int64 {
+	cacheCtx, _ := ctx.CacheContext()
+	return r.am.GetNextAccountNumber(cacheCtx)
+}
@@ -0,0 +1,115 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func newSendMsg(from, to sdk.AccAddress) bank.MsgSend {
+	coins := testutils.NewNativeTokens(1)
+	sdkfees.UnsetAllCalculators()
+	msg := bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+	sdkfees.RegisterCalculator(msg.Type(), sdkfees.FreeFeeCalculator())
+	return msg
+}
+
+func setupAccountCounter() (auth.AccountKeeper, sdk.Context, tx.AccountCounter) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, capKey))
+	counter := tx.NewAccountCounter(cdc, capKey2)
+	tx.SetAccountCounter(&counter)
+	return am, ctx, counter
+}
+
+func TestAnteHandlerMaxAccounts(t *testing.T) {
+	am, ctx, counter := setupAccountCounter()
+	defer tx.SetAccountCounter(nil)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	ctx.AccountCache().Write()
+	counter.InitAccountCounter(ctx, am)
+	require.Equal(t, int64(1), tx.TotalAccounts(ctx))
+
+	opts := tx.DefaultAnteOptions()
+	opts.MaxAccounts = 1
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	// at the cap, new accounts are rejected
+	_, addr2 := testutils.PrivAndAddr()
+	txn := newTestTx(ctx, []sdk.Msg{newSendMsg(acc1.GetAddress(), addr2)}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeTooManyAccounts), res.Code)
+
+	// but existing accounts can still receive coins
+	_, acc2 := testutils.NewAccount(ctx, am, 0)
+	txn = newTestTx(ctx, []sdk.Msg{newSendMsg(acc1.GetAddress(), acc2.GetAddress())}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
+
+func TestAccountLimitRouter(t *testing.T) {
+	am, ctx, _ := setupAccountCounter()
+	defer tx.SetAccountCounter(nil)
+	// the numbers assigned so far are not counted, like the ones skipped by an account number offset
+	testutils.NewAccount(ctx, am, 100)
+	require.Equal(t, int64(0), tx.TotalAccounts(ctx))
+
+	opts := tx.DefaultAnteOptions()
+	opts.MaxAccounts = 2
+
+	// a handler creating an account with any msg, not only a transfer
+	router := tx.NewAccountLimitRouter(baseapp.NewRouter(), am, opts)
+	router.AddRoute("create", func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		_, addr := testutils.PrivAndAddr()
+		am.SetAccount(ctx, am.NewAccountWithAddress(ctx, addr))
+		return sdk.Result{}
+	})
+	handler := router.Route("create")
+
+	for i := int64(1); i <= 2; i++ {
+		require.True(t, handler(ctx, sdk.NewTestMsg()).IsOK())
+		require.Equal(t, i, tx.TotalAccounts(ctx))
+	}
+	res := handler(ctx, sdk.NewTestMsg())
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeTooManyAccounts), res.Code)
+	require.Equal(t, int64(2), tx.TotalAccounts(ctx))
+}
+
+func TestInitAccountCounter(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, capKey))
+	counter := tx.NewAccountCounter(cdc, capKey2)
+	tx.SetAccountCounter(&counter)
+	defer tx.SetAccountCounter(nil)
+
+	for i := 0; i < 3; i++ {
+		testutils.NewAccount(ctx, am, 1)
+	}
+	ctx.AccountCache().Write()
+	require.NoError(t, types.SetAccountNumberOffset(ctx, am, 1000))
+	counter.InitAccountCounter(ctx, am)
+	require.Equal(t, int64(3), tx.TotalAccounts(ctx))
+}
@@ -0,0 +1,27 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/common/upgrade"
+)
+
+// checkAccountName rejects a named account whose name is set but invalid, names are optional.
+// The names of the validator accounts created at genesis come from the monikers, which may not be valid,
+// so it's only checked from the AccountNameCheck upgrade on, by when those accounts must have been renamed.
+func checkAccountName(acc sdk.Account) sdk.Error {
+	if !sdk.IsUpgrade(upgrade.AccountNameCheck) {
+		return nil
+	}
+	namedAcc, ok := acc.(types.NamedAccount)
+	if !ok || namedAcc.GetName() == "" {
+		return nil
+	}
+	if err := types.ValidateAccountName(namedAcc.GetName()); err != nil {
+		return sdk.ErrUnauthorized(fmt.Sprintf("invalid name of account %s: %v", acc.GetAddress(), err))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package tx_test
+
+import (
+	"math"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/common/upgrade"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAnteHandlerAccountName(t *testing.T) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	setName := func(name string) {
+		acc := am.GetAccount(ctx, acc1.GetAddress())
+		acc.(types.NamedAccount).SetName(name)
+		am.SetAccount(ctx, acc)
+	}
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+
+	// no name
+	checkValidTx(t, anteHandler, ctx, newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}), sdk.RunTxModeDeliver)
+
+	setName("alice.bnb")
+	checkValidTx(t, anteHandler, ctx, newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{1}), sdk.RunTxModeDeliver)
+
+	// a moniker of a genesis validator is accepted before the upgrade
+	setName("alice bnb")
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{2})
+	cacheCtx, _ := ctx.CacheContext()
+	checkValidTx(t, anteHandler, cacheCtx, txn, sdk.RunTxModeDeliver)
+
+	upgrade.Mgr.AddUpgradeHeight(upgrade.AccountNameCheck, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.AccountNameCheck, math.MaxInt64)
+	cacheCtx, _ = ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+}
@@ -3,6 +3,7 @@ package tx
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/libs/common"
 
 	"github.com/bnb-chain/node/common/log"
+	"github.com/bnb-chain/node/common/types"
 )
 
 const (
@@ -40,19 +42,26 @@ func newSigLRUCache(cap int) *sigLRUCache {
 	}
 }
 
-func (cache *sigLRUCache) getSig(txHash string) (ok bool) {
-	_, ok = cache.Get(txHash)
+func (cache *sigLRUCache) getSig(key string) (ok bool) {
+	_, ok = cache.Get(key)
 	return ok
 }
 
-func (cache *sigLRUCache) addSig(txHash string) {
-	if txHash != "" {
-		cache.Add(txHash, true)
-	}
+func (cache *sigLRUCache) addSig(key string) {
+	cache.Add(key, true)
+}
+
+// sigCacheKey is the hash of the pubkey, the sign bytes and the signature. A signature is verified
+// independently of the other signatures of the tx and of the account state, like the sequence,
+// so a valid signature stays valid when the tx is checked again.
+func sigCacheKey(pubKey crypto.PubKey, signBytes []byte, sig []byte) string {
+	bz := make([]byte, 0, len(pubKey.Bytes())+len(signBytes)+len(sig))
+	bz = append(append(append(bz, pubKey.Bytes()...), signBytes...), sig...)
+	return string(tmhash.Sum(bz))
 }
 
-// signature-key: txHash
-// based on the assumption that tx hash will never collide.
+// signature-key: sigCacheKey
+// based on the assumption that the hash will never collide.
 var sigCache = newSigLRUCache(defaultMaxCacheNumber)
 
 func InitSigCache(size int) {
@@ -61,6 +70,11 @@ func InitSigCache(size int) {
 
 // this function is not implemented in AnteHandler in BaseApp.
 func NewTxPreChecker() sdk.PreChecker {
+	return NewTxPreCheckerWithOptions(DefaultAnteOptions())
+}
+
+// NewTxPreCheckerWithOptions returns a PreChecker with the options, which should be the same as the ante handler's.
+func NewTxPreCheckerWithOptions(opts AnteOptions) sdk.PreChecker {
 	return func(ctx sdk.Context, txBytes []byte, tx sdk.Tx) (res sdk.Result) {
 		stdTx, ok := tx.(auth.StdTx)
 		if !ok {
@@ -74,41 +88,46 @@ func NewTxPreChecker() sdk.PreChecker {
 			}
 		}()
 
-		err := validateBasic(stdTx)
+		err := validateBasic(stdTx, opts)
 		if err != nil {
 			return err.Result()
 		}
 
-		// the below code are somewhat similar as part of AnteHandler,
-		// because it is extracted out to enable Concurrent run.
-		// It might be revised to reduce duplication but so far they are very light
-		sigs := stdTx.GetSignatures()
-		msgs := tx.GetMsgs()
-
-		// get the sign bytes (requires all account & sequence numbers and the fee)
-		sequences := make([]int64, len(sigs))
-		accNums := make([]int64, len(sigs))
-		for i := 0; i < len(sigs); i++ {
-			sequences[i] = sigs[i].Sequence
-			accNums[i] = sigs[i].AccountNumber
-		}
-
 		// TODO: optimization opportunity, txHash may be recalled later
 		txHash := common.HexBytes(tmhash.Sum(txBytes)).String()
-		chainID := ctx.ChainID()
+		if err := verifySignatures(ctx.ChainID(), txHash, stdTx, opts.sigVerifier()); err != nil {
+			return err.Result()
+		}
+		return sdk.Result{}
+	}
+}
 
-		// check sigs and nonce
-		for i := 0; i < len(sigs); i++ {
-			sig := sigs[i]
+// verifySignatures verifies the signatures of the tx with the pubkeys they carry.
+// the below code are somewhat similar as part of AnteHandler,
+// because it is extracted out to enable Concurrent run.
+// It might be revised to reduce duplication but so far they are very light
+func verifySignatures(chainID string, txHash string, stdTx auth.StdTx, verifier SignatureVerifier) sdk.Error {
+	sigs := stdTx.GetSignatures()
+	msgs := stdTx.GetMsgs()
+
+	// get the sign bytes (requires all account & sequence numbers and the fee)
+	sequences := make([]int64, len(sigs))
+	accNums := make([]int64, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		sequences[i] = sigs[i].Sequence
+		accNums[i] = sigs[i].AccountNumber
+	}
 
-			signBytes := auth.StdSignBytes(chainID, accNums[i], sequences[i], msgs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
-			res := processSig(txHash, sig, sig.PubKey, signBytes)
-			if !res.IsOK() {
-				return res
-			}
+	// check sigs and nonce
+	for i := 0; i < len(sigs); i++ {
+		sig := sigs[i]
+
+		signBytes := acceptedSignBytes(chainID, accNums[i], sequences[i], msgs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
+		if err := processSig(txHash, sig, sig.PubKey, signBytes, verifier); err != nil {
+			return err
 		}
-		return sdk.Result{}
 	}
+	return nil
 }
 
 // NewAnteHandler returns an AnteHandler that checks
@@ -119,6 +138,15 @@ func NewTxPreChecker() sdk.PreChecker {
 //
 // panic thrown in this function will be caught in RunTx
 func NewAnteHandler(am auth.AccountKeeper) sdk.AnteHandler {
+	return NewAnteHandlerWithOptions(am, DefaultAnteOptions())
+}
+
+// NewAnteHandlerWithOptions returns an AnteHandler same as NewAnteHandler, but with the given options.
+func NewAnteHandlerWithOptions(am auth.AccountKeeper, opts AnteOptions) sdk.AnteHandler {
+	checkDistributeTypeOverrides(opts.DistributeTypeOverrides)
+	checkDenomPrecisions(opts.DenomPrecisions)
+	checkSequenceOptions(opts)
+	rateLimiter := newTxRateLimiter(opts.MaxTxsPerAccountPerBlock)
 	return func(
 		ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode,
 	) (newCtx sdk.Context, res sdk.Result, abort bool) {
@@ -128,16 +156,50 @@ func NewAnteHandler(am auth.AccountKeeper) sdk.AnteHandler {
 		if !ok {
 			return newCtx, sdk.ErrInternal("tx must be StdTx").Result(), true
 		}
+		defer logSlowTx(ctx, wallClock(), stdTx)
+
+		txHash, _ := ctx.Value(baseapp.TxHashKey).(string)
+		if err := checkDuplicateTx(txHash, mode); err != nil {
+			return newCtx, err.Result(), true
+		}
 
 		if mode == sdk.RunTxModeDeliver ||
 			mode == sdk.RunTxModeCheck ||
 			mode == sdk.RunTxModeSimulate {
-			err := validateBasic(stdTx)
+			err := validateBasic(stdTx, opts)
 			if err != nil {
 				return newCtx, err.Result(), true
 			}
 		}
 
+		if err := checkChainHalted(tx.GetMsgs(), opts); err != nil {
+			return newCtx, err.Result(), true
+		}
+
+		if err := checkDistinctMsgTypes(tx.GetMsgs()); err != nil {
+			return newCtx, err.Result(), true
+		}
+
+		now := opts.clock().Now(ctx)
+		if err := checkBlockedTimeWindows(now, tx.GetMsgs(), opts.BlockedTimeWindows); err != nil {
+			return newCtx, err.Result(), true
+		}
+
+		if err := checkMaxAccounts(ctx, am, tx.GetMsgs(), opts.MaxAccounts); err != nil {
+			return newCtx, err.Result(), true
+		}
+
+		if err := rateLimiter.check(ctx, mode, stdTx.GetSigners()); err != nil {
+			return newCtx, err.Result(), true
+		}
+
+		// fees are not collected for the genesis txs, so there may be no calculators for them
+		if ctx.BlockHeight() != 0 {
+			if err := checkMsgTypes(tx.GetMsgs(), ctx.BlockHeight(), opts.Calculators); err != nil {
+				return newCtx, err.Result(), true
+			}
+		}
+
 		sigs := stdTx.GetSignatures()
 		signerAddrs := stdTx.GetSigners()
 		msgs := tx.GetMsgs()
@@ -152,24 +214,33 @@ func NewAnteHandler(am auth.AccountKeeper) sdk.AnteHandler {
 
 		// collect signer accounts
 		var signerAccs = make([]sdk.Account, len(signerAddrs))
-		txHash, _ := ctx.Value(baseapp.TxHashKey).(string)
 		chainID := ctx.ChainID()
+		var pubKeysSet []sdk.AccAddress
 		// check sigs and nonce
 		for i := 0; i < len(sigs); i++ {
 			signerAddr, sig := signerAddrs[i], sigs[i]
-			signerAcc, err := processAccount(newCtx, am, signerAddr, sig, true)
+			signerAcc, pubKeySet, err := processAccount(newCtx, am, signerAddr, sig, now, true, opts)
 			if err != nil {
 				return newCtx, err.Result(), true
 			}
+			if pubKeySet {
+				pubKeysSet = append(pubKeysSet, signerAddr)
+			}
+			if err := checkAccountName(signerAcc); err != nil {
+				return newCtx, err.Result(), true
+			}
+			// the pubkey of the account rather than its address is matched, as a migrated account keeps its address
+			if opts.StrictSignerOrder && !signerAcc.GetPubKey().Equals(sig.PubKey) {
+				return newCtx, sdk.ErrUnauthorized(fmt.Sprintf("signature %d is not of the signer %s", i, signerAddr)).Result(), true
+			}
 
 			if mode == sdk.RunTxModeDeliver ||
 				mode == sdk.RunTxModeCheck ||
 				mode == sdk.RunTxModeSimulate {
 				// check signature, return account with incremented nonce
-				signBytes := auth.StdSignBytes(chainID, accNums[i], sequences[i], msgs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
-				res := processSig(txHash, sig, signerAcc.GetPubKey(), signBytes)
-				if !res.IsOK() {
-					return newCtx, res, true
+				signBytes := acceptedSignBytes(chainID, accNums[i], sequences[i], msgs, stdTx.GetMemo(), stdTx.GetSource(), stdTx.GetData())
+				if err := processSig(txHash, sig, signerAcc.GetPubKey(), signBytes, opts.sigVerifier()); err != nil {
+					return newCtx, err.Result(), true
 				}
 			} else {
 				// if we do not processSig here, we should make sure pubKey of signature is identical to pubKey of account
@@ -184,8 +255,14 @@ func NewAnteHandler(am auth.AccountKeeper) sdk.AnteHandler {
 		}
 
 		// for blockHeight == 0, we do not collect fees since we have some StdTx(s) in InitChain.
+		// BaseApp rejects the txs without exactly one msg in validateBasicTxMsgs, so msgs[0] decides the payer
+		// of the whole fee.
 		if newCtx.BlockHeight() != 0 {
-			res = calcAndCollectFees(newCtx, am, signerAccs[0], msgs[0], txHash)
+			feePayer, err := getFeePayer(newCtx, am, signerAccs, msgs[0])
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
+			res = calcAndCollectFees(newCtx, am, feePayer, msgs, stdTx.GetMemo(), txHash, mode, opts)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -193,14 +270,32 @@ func NewAnteHandler(am auth.AccountKeeper) sdk.AnteHandler {
 
 		// cache the signer accounts in the context
 		newCtx = auth.WithSigners(newCtx, signerAccs)
+		// a dry run by AnteCheckOnly leaves no trace in the caches of the ante handler
+		if !isAnteCheckOnly(ctx) {
+			addCheckedTx(txHash, mode)
+			rateLimiter.add(ctx, mode, signerAddrs)
+		}
 
-		// TODO: tx tags (?)
-		return newCtx, sdk.Result{}, false // continue...
+		tags := pubKeySetTags(pubKeysSet).AppendTags(res.Tags)
+		newCtx = withAnteTags(newCtx, tags)
+		return newCtx, sdk.Result{Tags: tags}, false // continue...
 	}
 }
 
-// Validate the transaction based on things that don't depend on the context
-func validateBasic(tx auth.StdTx) (err sdk.Error) {
+// ValidateBasic validates the transaction based on things that don't depend on the context,
+// so it can also be used to check the structure of a tx offline.
+func ValidateBasic(tx auth.StdTx) (err sdk.Error) {
+	return validateBasic(tx, DefaultAnteOptions())
+}
+
+func validateBasic(tx auth.StdTx, opts AnteOptions) (err sdk.Error) {
+	if len(tx.GetMsgs()) == 0 {
+		return sdk.ErrUnauthorized("no messages in transaction")
+	}
+	if opts.MaxMsgsPerTx > 0 && len(tx.GetMsgs()) > opts.MaxMsgsPerTx {
+		return sdk.ErrUnauthorized(fmt.Sprintf("too many messages in transaction, got %d, max %d", len(tx.GetMsgs()), opts.MaxMsgsPerTx))
+	}
+
 	// Assert that there are signatures.
 	sigs := tx.GetSignatures()
 	if len(sigs) == 0 {
@@ -211,6 +306,12 @@ func validateBasic(tx auth.StdTx) (err sdk.Error) {
 		if sig.PubKey == nil {
 			return sdk.ErrInvalidPubKey("public key of signature should not be nil")
 		}
+		if err := checkPubKeyType(sig.PubKey); err != nil {
+			return err
+		}
+	}
+	if err := checkDuplicateSigners(sigs); err != nil {
+		return err
 	}
 
 	// Assert that number of signatures is correct.
@@ -233,45 +334,57 @@ func validateBasic(tx auth.StdTx) (err sdk.Error) {
 		return sdk.ErrUnauthorized("data field is not allowed to use in transaction for now")
 	}
 
-	if memo := tx.GetMemo(); len(memo) > maxMemoCharacters {
+	if memo, maxMemo := tx.GetMemo(), maxMemoCharactersOf(tx, opts.MaxMemoBytes); len(memo) > maxMemo {
 		return sdk.ErrMemoTooLarge(
 			fmt.Sprintf("maximum number of characters is %d but received %d characters",
-				maxMemoCharacters, len(memo)))
+				maxMemo, len(memo)))
 	}
 	return nil
 }
 
 func processAccount(ctx sdk.Context, am auth.AccountKeeper,
-	addr sdk.AccAddress, sig auth.StdSignature, setSeq bool) (acc sdk.Account, err sdk.Error) {
+	addr sdk.AccAddress, sig auth.StdSignature, now time.Time, setSeq bool, opts AnteOptions) (acc sdk.Account, pubKeySet bool, err sdk.Error) {
 	// Get the account.
 	acc = am.GetAccount(ctx, addr)
 	if acc == nil {
-		return nil, sdk.ErrUnknownAddress(addr.String())
+		return nil, false, sdk.ErrUnknownAddress(
+			fmt.Sprintf("account %s does not exist, it needs to be funded before it can sign a tx", addr))
+	}
+
+	// unlock the vesting coins which have matured and thaw the expired frozen coins,
+	// before anything is charged
+	if namedAcc, ok := acc.(types.NamedAccount); ok {
+		namedAcc.UnlockMaturedCoins(now.Unix())
+		namedAcc.ThawExpiredCoins(ctx.BlockHeight())
 	}
 
 	// On InitChain, make sure account number == 0
 	if ctx.BlockHeight() == 0 {
 		if sig.AccountNumber != 0 {
-			return nil, sdk.ErrInvalidSequence(
+			return nil, false, sdk.ErrInvalidSequence(
 				fmt.Sprintf("Invalid account number for BlockHeight == 0. Got %d, expected 0", sig.AccountNumber))
 		}
 	} else {
 		// Check account number.
 		accnum := acc.GetAccountNumber()
 		if accnum != sig.AccountNumber {
-			return nil, sdk.ErrInvalidSequence(
+			return nil, false, sdk.ErrInvalidSequence(
 				fmt.Sprintf("Invalid account number. Got %d, expected %d", sig.AccountNumber, accnum))
 		}
 	}
 
-	if setSeq {
+	if setSeq && opts.SequenceWindow > 1 {
+		if err := opts.SequenceWindowKeeper.acceptSequence(ctx, acc, sig.Sequence, opts.SequenceWindow); err != nil {
+			return nil, false, err
+		}
+	} else if setSeq {
 		// Check and increment sequence number.
 		seq := acc.GetSequence()
 		if seq != sig.Sequence {
-			return nil, sdk.ErrInvalidSequence(
+			return nil, false, sdk.ErrInvalidSequence(
 				fmt.Sprintf("Invalid sequence. Got %d, expected %d", sig.Sequence, seq))
 		}
-		errSeq := acc.SetSequence(seq + 1)
+		errSeq := acc.SetSequence(seq + opts.sequenceIncrement())
 		if errSeq != nil {
 			// Handle w/ #870
 			panic(err)
@@ -283,54 +396,66 @@ func processAccount(ctx sdk.Context, am auth.AccountKeeper,
 	if pubKey == nil {
 		pubKey = sig.PubKey
 		if pubKey == nil {
-			return nil, sdk.ErrInvalidPubKey("PubKey not found")
+			return nil, false, sdk.ErrInvalidPubKey("PubKey not found")
 		}
 		if !bytes.Equal(pubKey.Address(), addr) {
-			return nil, sdk.ErrInvalidPubKey(
-				fmt.Sprintf("PubKey does not match Signer address %v", addr))
+			return nil, false, sdk.ErrInvalidPubKey(
+				fmt.Sprintf("account exists but PubKey does not match Signer address %v", addr))
 		}
 		errKey := acc.SetPubKey(pubKey)
 		if errKey != nil {
-			return nil, sdk.ErrInternal("setting PubKey on signer's account")
+			return nil, false, sdk.ErrInternal("setting PubKey on signer's account")
 		}
+		if accountCreationKeeper != nil {
+			accountCreationKeeper.addCreatedAccount(ctx, addr)
+		}
+		pubKeySet = true
 	}
 
-	return acc, nil
+	return acc, pubKeySet, nil
 }
 
 // verify the signature and increment the sequence.
 // if the account doesn't have a pubkey, set it.
 func processSig(txHash string,
-	sig auth.StdSignature, pubKey crypto.PubKey, signBytes []byte) (
-	res sdk.Result) {
-
-	if sigCache.getSig(txHash) {
-		log.Debug("Tx hits sig cache", "txHash", txHash)
-		return
+	sig auth.StdSignature, pubKey crypto.PubKey, signBytes [][]byte, verifier SignatureVerifier) sdk.Error {
+
+	// the sig cache is shared by all the ante handlers, so only the default verifier uses it
+	_, cached := verifier.(pubKeyVerifier)
+	keys := make([]string, len(signBytes))
+	for i, bz := range signBytes {
+		keys[i] = sigCacheKey(pubKey, bz, sig.Signature)
+		if cached && sigCache.getSig(keys[i]) {
+			log.Debug("Tx hits sig cache", "txHash", txHash)
+			return nil
+		}
 	}
 
-	// Check sig.
-	if !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return sdk.ErrUnauthorized("signature verification failed").Result()
+	// Check sig against the sign bytes of all the accepted versions.
+	for i, bz := range signBytes {
+		if verifier.Verify(pubKey, bz, sig.Signature) {
+			if cached {
+				sigCache.addSig(keys[i])
+			}
+			return nil
+		}
 	}
-
-	sigCache.addSig(txHash)
-	return
+	return sdk.ErrUnauthorized("signature verification failed")
 }
 
-func calcAndCollectFees(ctx sdk.Context, am auth.AccountKeeper, acc sdk.Account, msg sdk.Msg, txHash string) sdk.Result {
+func calcAndCollectFees(ctx sdk.Context, am auth.AccountKeeper, acc sdk.Account, msgs []sdk.Msg, memo string, txHash string,
+	mode sdk.RunTxMode, opts AnteOptions) sdk.Result {
 	// first sig pays the fees
 	// Can this function be moved outside of the loop?
 
-	fee, err := calculateFees(msg)
-	if err != nil {
-		ctx.Logger().Error("calculate fees error", "err", err.Error())
-		return sdk.ErrInternal("calculate fees error").Result()
+	fee, sdkErr := resolveFee(ctx, acc.GetAddress(), msgs, memo, mode, opts)
+	if sdkErr != nil {
+		return sdkErr.Result()
 	}
 
 	if fee.Type != sdk.FeeFree && !fee.Tokens.IsZero() {
 		fee.Tokens.Sort()
-		res := deductFees(ctx, acc, fee, am)
+		res := deductFees(ctx, acc, fee, am, opts)
 		if !res.IsOK() {
 			return res
 		}
@@ -339,16 +464,93 @@ func calcAndCollectFees(ctx sdk.Context, am auth.AccountKeeper, acc sdk.Account,
 	if ctx.IsDeliverTx() {
 		// add fee to pool, even it's free
 		sdkfees.Pool.AddFee(txHash, fee)
+		if !fee.Tokens.IsZero() {
+			FeePayers.AddPayment(txHash, acc.GetAddress(), fee.Tokens)
+			if opts.FeeDistributionHook != nil {
+				opts.FeeDistributionHook(ctx, fee.Tokens, int8(fee.Type))
+			}
+		}
 	}
-	return sdk.Result{}
+	return sdk.Result{Tags: feeTags(fee)}
 }
 
-func calculateFees(msg sdk.Msg) (sdk.Fee, error) {
-	calculator := sdkfees.GetCalculator(msg.Type())
+// resolveFee returns the fee the payer is charged for the msgs, after applying the overrides and exemptions
+func resolveFee(ctx sdk.Context, payer sdk.AccAddress, msgs []sdk.Msg, memo string,
+	mode sdk.RunTxMode, opts AnteOptions) (sdk.Fee, sdk.Error) {
+	if isFeeExempt(ctx, payer) {
+		return sdk.NewFee(sdk.Coins{}, sdk.FeeFree), nil
+	}
+
+	charged := make([]sdk.Msg, 0, len(msgs))
+	for _, msg := range msgs {
+		if !isSimulationFree(msg, mode) {
+			charged = append(charged, msg)
+		}
+	}
+	fee, err := calculateTotalFee(charged, ctx.BlockHeight(), loadFeeParams(ctx), opts)
+	if sdkErr, ok := err.(sdk.Error); ok {
+		return sdk.Fee{}, sdkErr
+	} else if err != nil {
+		ctx.Logger().Error("calculate fees error", "err", err.Error())
+		return sdk.Fee{}, sdk.ErrInternal("calculate fees error")
+	}
+
+	if err := validateFeeDenoms(fee, opts.feeDenomValidator()); err != nil {
+		return sdk.Fee{}, err
+	}
+
+	if dt, ok := memoDistributeType(memo, payer); ok && fee.Type != sdk.FeeFree {
+		fee.Type = dt
+	}
+	return fee, nil
+}
+
+// calculateTotalFee sums the fees of the msgs. The total is distributed to all the validators if any msg
+// asks so, otherwise to the proposer if any msg is charged, and it's free if all the msgs are free.
+// The txs run by BaseApp have exactly one msg, so it's the fee of that msg for them, the sum is only
+// for the txs passed to the ante handler directly.
+func calculateTotalFee(msgs []sdk.Msg, height int64, params FeeParams, opts AnteOptions) (sdk.Fee, error) {
+	total := sdk.NewFee(sdk.Coins{}, sdk.FeeFree)
+	for _, msg := range msgs {
+		fee, err := calculateFees(msg, height, params, opts)
+		if err != nil {
+			return sdk.Fee{}, err
+		}
+		if err := checkFreeAllowed(msg, fee, opts.EnforceFreeAllowlist); err != nil {
+			return sdk.Fee{}, err
+		}
+		if fee.Type == sdk.FeeFree {
+			continue
+		}
+		total.Tokens = total.Tokens.Plus(fee.Tokens.Sort())
+		if total.Type == sdk.FeeFree || fee.Type == sdk.FeeForAll {
+			total.Type = fee.Type
+		}
+	}
+	return total, nil
+}
+
+func calculateFees(msg sdk.Msg, height int64, params FeeParams, opts AnteOptions) (sdk.Fee, error) {
+	calculator := opts.Calculators.get(msg.Type(), height, params)
 	if calculator == nil {
 		return sdk.Fee{}, errors.New("missing calculator for msgType:" + msg.Type())
 	}
-	return calculator(msg), nil
+	fee := calculator(msg)
+	if dt, ok := opts.DistributeTypeOverrides[msg.Type()]; ok && fee.Type != sdk.FeeFree {
+		fee.Type = dt
+	}
+	fee.Tokens = RoundFee(fee.Tokens, opts.DenomPrecisions)
+	return fee, nil
+}
+
+// checkMsgTypes makes sure every msg is of a known type, i.e. a fee calculator is registered for it
+func checkMsgTypes(msgs []sdk.Msg, height int64, calculators CalculatorRegistry) sdk.Error {
+	for _, msg := range msgs {
+		if calculators.get(msg.Type(), height, FeeParams{}) == nil {
+			return sdk.ErrUnknownRequest("unknown msg type: " + msg.Type())
+		}
+	}
+	return nil
 }
 
 func checkSufficientFunds(acc sdk.Account, fee sdk.Fee) sdk.Result {
@@ -363,7 +565,10 @@ func checkSufficientFunds(acc sdk.Account, fee sdk.Fee) sdk.Result {
 	return sdk.Result{}
 }
 
-func deductFees(ctx sdk.Context, acc sdk.Account, fee sdk.Fee, am auth.AccountKeeper) sdk.Result {
+func deductFees(ctx sdk.Context, acc sdk.Account, fee sdk.Fee, am auth.AccountKeeper, opts AnteOptions) sdk.Result {
+	if opts.PayFeesFromLockedCoins {
+		unlockCoinsForFee(acc, fee)
+	}
 	if res := checkSufficientFunds(acc, fee); !res.IsOK() {
 		return res
 	}
@@ -376,5 +581,8 @@ func deductFees(ctx sdk.Context, acc sdk.Account, fee sdk.Fee, am auth.AccountKe
 	}
 
 	am.SetAccount(ctx, acc)
+	if feePaidKeeper != nil {
+		feePaidKeeper.AddFeesPaid(ctx, acc.GetAddress(), fee.Tokens)
+	}
 	return sdk.Result{}
 }
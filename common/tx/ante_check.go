@@ -0,0 +1,27 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the context key marking a dry run of the ante handler by AnteCheckOnly
+type anteCheckOnlyKey struct{}
+
+func isAnteCheckOnly(ctx sdk.Context) bool {
+	checkOnly, _ := ctx.Value(anteCheckOnlyKey{}).(bool)
+	return checkOnly
+}
+
+// AnteCheckOnly runs the ante handler against a cached context which is discarded afterwards,
+// so the block proposer can filter out the txs that would fail the ante checks without changing any state.
+// The ante handler should be the one of the app, e.g. built by NewAnteHandlerWithOptions with its options.
+// The tx is not counted by its rate limiter nor added to the duplicate tx cache.
+func AnteCheckOnly(anteHandler sdk.AnteHandler, ctx sdk.Context, tx sdk.Tx) (ok bool, reason string) {
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithRunTxMode(sdk.RunTxModeCheck).WithValue(anteCheckOnlyKey{}, true)
+	_, res, abort := anteHandler(cacheCtx, tx, sdk.RunTxModeCheck)
+	if abort || !res.IsOK() {
+		return false, res.Log
+	}
+	return true, ""
+}
@@ -0,0 +1,57 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteCheckOnly(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), acc1.GetAddress())
+
+	// bad sequence
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	ok, reason := tx.AnteCheckOnly(anteHandler, ctx, txn)
+	require.False(t, ok)
+	require.Contains(t, reason, "sequence")
+
+	// good tx, nothing is committed
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	ok, reason = tx.AnteCheckOnly(anteHandler, ctx, txn)
+	require.True(t, ok)
+	require.Empty(t, reason)
+	require.Equal(t, int64(0), am.GetAccount(ctx, acc1.GetAddress()).GetSequence())
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+}
+
+func TestAnteCheckOnlyLeavesNoTrace(t *testing.T) {
+	am, ctx, _ := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck).WithValue(baseapp.TxHashKey, "tx1")
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+	opts := tx.DefaultAnteOptions()
+	opts.MaxTxsPerAccountPerBlock = 1
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	tx.InitDuplicateTxCache(10)
+	defer tx.InitDuplicateTxCache(0)
+
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	for i := 0; i < 2; i++ {
+		ok, reason := tx.AnteCheckOnly(anteHandler, ctx, txn)
+		require.True(t, ok, reason)
+	}
+	// neither rate limited nor rejected as a duplicate
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+}
@@ -0,0 +1,128 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// AnteOptions are the settings of an ante handler that chains forking this code may want to tune
+type AnteOptions struct {
+	// MaxMemoBytes is the max length of the memo of a tx
+	MaxMemoBytes int
+	// Calculators are the fee calculators used instead of the ones registered in sdkfees, nil means the latter
+	Calculators CalculatorRegistry
+	// MaxMsgsPerTx is the max number of msgs in a tx, 0 means no limit
+	MaxMsgsPerTx int
+	// MaxTxsPerAccountPerBlock is the max number of txs a signer can land in a block, 0 means no limit
+	MaxTxsPerAccountPerBlock int
+	// FeeDistributionHook is called in DeliverTx after the fee is added to the fee pool, with the coins actually
+	// collected and the sdk.FeeDistributeType of the fee, e.g. to burn a part of it. nil means no hook.
+	FeeDistributionHook FeeDistributionHook
+	// StrictSignerOrder requires the pubkey of every signature to be of the signer at the same position,
+	// rather than only verifying the signature with the pubkey already known for the signer
+	StrictSignerOrder bool
+	// Clock provides the time the blocked time windows and the lock schedules are checked against,
+	// nil means the block time
+	Clock Clock
+	// SignatureVerifier verifies the signatures of the txs, e.g. for a new signature scheme, nil means
+	// pubKey.VerifyBytes. The signatures verified by a custom verifier are not shared through the sig cache.
+	SignatureVerifier SignatureVerifier
+	// PayFeesFromLockedCoins unlocks the minimum amount of locked coins needed to pay a fee, when the free coins
+	// of the account are not enough. The locked coins of an account must equal the remaining quantities of its
+	// open orders, as the DEX unlocks them when the orders are filled, canceled or expired. So they are only
+	// unlocked for an account without open orders, as told by the checker set with SetOpenOrderChecker, and
+	// never without a checker.
+	PayFeesFromLockedCoins bool
+	// EnforceFreeAllowlist rejects the msgs which come out free but are not registered by RegisterFreeCalculator,
+	// so a misconfigured calculator can't make a paid msg free
+	EnforceFreeAllowlist bool
+	// FeeDenomValidator decides which denoms the fees can be charged in, nil means only the native token
+	FeeDenomValidator FeeDenomValidator
+	// DistributeTypeOverrides override the distribute types of the fees of the msg types they are keyed by, while
+	// the amounts are still the ones returned by the calculators. Only FeeForProposer and FeeForAll are allowed,
+	// and free fees are not affected, as they are not charged at all.
+	DistributeTypeOverrides map[string]sdk.FeeDistributeType
+	// DenomPrecisions are the decimals of the denoms whose smallest unit is larger than the 1e-8 of the chain
+	// amounts, the fees in them are rounded to their smallest units by RoundFee. They should be in
+	// [0, types.TokenDecimals].
+	DenomPrecisions map[string]int8
+	// SequenceWindow lets the txs of an account be executed out of order, any unused sequence within
+	// [acc.GetSequence(), acc.GetSequence()+SequenceWindow) is accepted. It should be at most 64, and 0 or 1
+	// means strict sequence checking.
+	SequenceWindow int64
+	// SequenceWindowKeeper stores the used sequences of the accounts, it's required for a SequenceWindow above 1
+	SequenceWindowKeeper *SequenceWindowKeeper
+	// SequenceIncrement is the step the sequence advances by for each accepted tx with strict sequence checking,
+	// 0 means 1
+	SequenceIncrement int64
+	// MaxAccounts makes the msgs which would create new accounts beyond it fail, 0 means no limit. The accounts
+	// are counted by the counter set by SetAccountCounter, there is no limit without it.
+	MaxAccounts int64
+	// ChainHalted rejects all the txs except the ones carrying only the HaltAllowedMsgTypes, e.g. the governance
+	// msgs to unhalt the chain
+	ChainHalted bool
+	// HaltAllowedMsgTypes are the msg types which are still allowed when the chain is halted
+	HaltAllowedMsgTypes []string
+	// BlockedTimeWindows are the time windows in which txs are rejected, e.g. for maintenance
+	BlockedTimeWindows []TimeWindow
+}
+
+// FeeDistributionHook lets a chain distribute the collected fees beyond the proposer/all distribution
+type FeeDistributionHook func(ctx sdk.Context, fee sdk.Coins, mode int8)
+
+// DefaultAnteOptions returns the options used by NewAnteHandler and NewTxPreChecker
+func DefaultAnteOptions() AnteOptions {
+	return AnteOptions{
+		MaxMemoBytes: maxMemoCharacters,
+	}
+}
+
+// CalculatorRegistry is a set of fee calculators keyed by msg type, which an ante handler can use instead of
+// the global ones, so that e.g. tests running in parallel don't interfere with each other.
+type CalculatorRegistry map[string]sdkfees.FeeCalculator
+
+// SnapshotCalculators returns the calculators registered in sdkfees, e.g. for a test to restore them with
+// RestoreCalculators once it's done. sdkfees doesn't list its calculators, so only the ones of the msg types
+// in sdkfees.CalculatorsGen, the ones registered by RegisterFreeCalculator and the given ones are taken.
+func SnapshotCalculators(msgTypes ...string) CalculatorRegistry {
+	snapshot := make(CalculatorRegistry)
+	add := func(msgType string) {
+		if calculator := sdkfees.GetCalculator(msgType); calculator != nil {
+			snapshot[msgType] = calculator
+		}
+	}
+	for msgType := range sdkfees.CalculatorsGen {
+		add(msgType)
+	}
+	for msgType := range freeMsgTypes {
+		add(msgType)
+	}
+	for _, msgType := range msgTypes {
+		add(msgType)
+	}
+	return snapshot
+}
+
+// RestoreCalculators replaces the calculators registered in sdkfees with the snapshot
+func RestoreCalculators(snapshot CalculatorRegistry) {
+	sdkfees.UnsetAllCalculators()
+	for msgType, calculator := range snapshot {
+		sdkfees.RegisterCalculator(msgType, calculator)
+	}
+}
+
+// get returns the calculator of the msg type at the height with the fee params. Without a registry, the height
+// aware calculators registered by RegisterHeightAwareCalculator come first, then the ones registered by
+// RegisterParamsCalculator, then the ones registered in sdkfees.
+func (r CalculatorRegistry) get(msgType string, height int64, params FeeParams) sdkfees.FeeCalculator {
+	if r != nil {
+		return r[msgType]
+	}
+	if calculator, ok := heightAwareCalculators[msgType]; ok {
+		return func(msg sdk.Msg) sdk.Fee { return calculator(height, msg) }
+	}
+	if calculator, ok := paramsCalculators[msgType]; ok {
+		return func(msg sdk.Msg) sdk.Fee { return calculator(params, msg) }
+	}
+	return sdkfees.GetCalculator(msgType)
+}
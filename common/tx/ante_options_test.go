@@ -0,0 +1,131 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerWithOptionsMaxMemoBytes(t *testing.T) {
+	am, ctx, _ := setup()
+	anteHandler := tx.NewAnteHandlerWithOptions(am, tx.AnteOptions{MaxMemoBytes: 10})
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	// at the boundary
+	txn := newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}, strings.Repeat("m", 10))
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	// over the boundary
+	txn = newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1}, strings.Repeat("m", 11))
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeMemoTooLarge, sdk.RunTxModeDeliver)
+
+	// the pre-checker with the same options agrees
+	preChecker := tx.NewTxPreCheckerWithOptions(tx.AnteOptions{MaxMemoBytes: 10})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMemoTooLarge), preChecker(ctx, nil, txn).Code)
+}
+
+func TestAnteHandlerWithOptionsCalculators(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		fee  int64
+	}{
+		{"fee 10", 10},
+		{"fee 20", 20},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			am, ctx, _ := setup()
+			ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+			priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+			msg := sdk.NewTestMsg(acc1.GetAddress())
+			anteHandler := tx.NewAnteHandlerWithOptions(am, tx.AnteOptions{
+				MaxMemoBytes: tx.DefaultAnteOptions().MaxMemoBytes,
+				Calculators:  tx.CalculatorRegistry{msg.Type(): sdkfees.FixedFeeCalculator(c.fee, sdk.FeeForProposer)},
+			})
+
+			for i := int64(0); i < 3; i++ {
+				txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{i})
+				checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+			}
+			checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100-3*c.fee)})
+		})
+	}
+}
+
+func TestAnteHandlerWithOptionsMaxMsgsPerTx(t *testing.T) {
+	am, ctx, _ := setup()
+	opts := tx.DefaultAnteOptions()
+	opts.MaxMsgsPerTx = 3
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+	privs, accNums := []crypto.PrivKey{priv1}, []int64{0}
+
+	// no msgs
+	txn := newTestTx(ctx, []sdk.Msg{}, privs, accNums, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "no messages in transaction")
+
+	// too many msgs
+	txn = newTestTx(ctx, []sdk.Msg{msg, msg, msg, msg}, privs, accNums, []int64{0})
+	_, res, abort = anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "too many messages")
+
+	txn = newTestTx(ctx, []sdk.Msg{msg, msg, msg}, privs, accNums, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
+
+func TestSnapshotCalculators(t *testing.T) {
+	msgType := sdk.NewTestMsg().Type()
+	sdkfees.UnsetAllCalculators()
+	sdkfees.RegisterCalculator(msgType, sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	snapshot := tx.SnapshotCalculators(msgType)
+	defer sdkfees.UnsetAllCalculators()
+
+	sdkfees.RegisterCalculator(msgType, sdkfees.FixedFeeCalculator(20, sdk.FeeForAll))
+	sdkfees.RegisterCalculator("other", sdkfees.FreeFeeCalculator())
+	tx.RestoreCalculators(snapshot)
+
+	fee := sdkfees.GetCalculator(msgType)(sdk.NewTestMsg())
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForProposer), fee)
+	require.Nil(t, sdkfees.GetCalculator("other"))
+}
+
+func TestEstimateFeeWithOptionsCalculators(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := sdk.NewTestMsg(acc1.GetAddress())
+	sdkfees.UnsetAllCalculators()
+	opts := tx.DefaultAnteOptions()
+	opts.Calculators = tx.CalculatorRegistry{msg.Type(): sdkfees.FixedFeeCalculator(7, sdk.FeeForAll)}
+
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	fee, err := tx.EstimateFeeWithOptions(ctx, txn, opts)
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 7)}, sdk.FeeForAll), fee)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 7)}, tx.EstimateBlockFeesWithOptions(ctx, []auth.StdTx{txn}, opts))
+	require.Equal(t, map[string]tx.FeeDescription{
+		msg.Type(): {Fee: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 7)}, Distribution: "all"},
+	}, tx.DescribeFeesWithOptions(ctx, opts))
+
+	// the global calculators are not used
+	_, err = tx.EstimateFee(ctx, txn)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,48 @@
+package tx
+
+import (
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// baseapp.RunTx drops the result of the ante handler once it passes, so the tags of the ante handler
+// (e.g. the fee deducted and the pubkeys set) are kept in the context it returns, and added to the result
+// of the msg by the handlers of the router returned by NewAnteTagsRouter.
+type anteTagsKey struct{}
+
+func withAnteTags(ctx sdk.Context, tags sdk.Tags) sdk.Context {
+	if len(tags) == 0 {
+		return ctx
+	}
+	return ctx.WithValue(anteTagsKey{}, tags)
+}
+
+// AnteTags returns the tags the ante handler produced for the tx being run
+func AnteTags(ctx sdk.Context) sdk.Tags {
+	tags, _ := ctx.Value(anteTagsKey{}).(sdk.Tags)
+	return tags
+}
+
+type anteTagsRouter struct {
+	baseapp.Router
+}
+
+// NewAnteTagsRouter wraps the router so that the tags of the ante handler come first in the result of
+// the msg handlers added through it.
+func NewAnteTagsRouter(router baseapp.Router) baseapp.Router {
+	return anteTagsRouter{router}
+}
+
+func (r anteTagsRouter) AddRoute(path string, handler sdk.Handler) baseapp.Router {
+	r.Router.AddRoute(path, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		res := handler(ctx, msg)
+		// the state, including the fee, is not written if the msg fails
+		if res.IsOK() {
+			if tags := AnteTags(ctx); len(tags) != 0 {
+				res.Tags = append(append(sdk.Tags{}, tags...), res.Tags...)
+			}
+		}
+		return res
+	})
+	return r
+}
@@ -160,6 +160,29 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeUnknownAddress, sdk.RunTxModeCheck)
 }
 
+func TestAnteHandlerUnknownAddressMessage(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, addr1 := testutils.PrivAndAddr()
+	priv2, _ := testutils.PrivAndAddr()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// the account does not exist
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeCheck)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownAddress), res.Code)
+	require.Contains(t, res.Log, addr1.String())
+	require.Contains(t, res.Log, "needs to be funded")
+
+	// the account exists, but the tx is signed by another key
+	am.SetAccount(ctx, am.NewAccountWithAddress(ctx, addr1))
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{priv2}, []int64{0}, []int64{0})
+	_, res, abort = anteHandler(ctx, txn, sdk.RunTxModeCheck)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidPubKey), res.Code)
+	require.Contains(t, res.Log, "account exists")
+}
+
 // Test logic around account number checking with one signer and many signers.
 func TestAnteHandlerAccountNumbers(t *testing.T) {
 	// setup
@@ -0,0 +1,34 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// CanTransact tells whether the account is able to send a tx paying the fee at the moment, and why if it's not:
+// the account should exist and have enough coins for the fee, and txs should not be blocked or halted.
+// Msg type specific rules are not taken into account.
+func CanTransact(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, fee sdk.Coins) (bool, string) {
+	return CanTransactWithOptions(ctx, am, addr, fee, DefaultAnteOptions())
+}
+
+// CanTransactWithOptions tells the same as CanTransact, but for an ante handler with the given options.
+func CanTransactWithOptions(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, fee sdk.Coins, opts AnteOptions) (bool, string) {
+	if opts.ChainHalted {
+		return false, "chain is halted"
+	}
+	if err := checkBlockedTimeWindows(opts.clock().Now(ctx), nil, opts.BlockedTimeWindows); err != nil {
+		return false, err.RawError()
+	}
+
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return false, fmt.Sprintf("account %s does not exist", addr)
+	}
+	if coins := acc.GetCoins(); !coins.Minus(fee.Sort()).IsNotNegative() {
+		return false, fmt.Sprintf("insufficient fund. you got %s, but %s fee needed.", coins, fee)
+	}
+	return true, ""
+}
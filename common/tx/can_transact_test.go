@@ -0,0 +1,51 @@
+package tx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestCanTransact(t *testing.T) {
+	am, ctx, _ := setup()
+	_, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, addr2 := testutils.PrivAndAddr()
+	fee := sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 50)}
+
+	// healthy account
+	ok, reason := tx.CanTransact(ctx, am, acc1.GetAddress(), fee)
+	require.True(t, ok)
+	require.Empty(t, reason)
+
+	// underfunded account
+	ok, reason = tx.CanTransact(ctx, am, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 200)})
+	require.False(t, ok)
+	require.Contains(t, reason, "insufficient fund")
+
+	// missing account
+	ok, reason = tx.CanTransact(ctx, am, addr2, fee)
+	require.False(t, ok)
+	require.Contains(t, reason, "does not exist")
+
+	// blocked by a time window
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts := tx.DefaultAnteOptions()
+	opts.BlockedTimeWindows = []tx.TimeWindow{{Start: start, End: start.Add(time.Hour)}}
+	ok, reason = tx.CanTransactWithOptions(ctx.WithBlockTime(start), am, acc1.GetAddress(), fee, opts)
+	require.False(t, ok)
+	require.Contains(t, reason, "not allowed")
+
+	// chain halted
+	opts = tx.DefaultAnteOptions()
+	opts.ChainHalted = true
+	ok, reason = tx.CanTransactWithOptions(ctx, am, acc1.GetAddress(), fee, opts)
+	require.False(t, ok)
+	require.Equal(t, "chain is halted", reason)
+}
@@ -0,0 +1,26 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func checkChainHalted(msgs []sdk.Msg, opts AnteOptions) sdk.Error {
+	if !opts.ChainHalted {
+		return nil
+	}
+	for _, msg := range msgs {
+		if !opts.haltAllowed(msg.Type()) {
+			return ErrChainHalted("chain is halted, msg type " + msg.Type() + " is not allowed")
+		}
+	}
+	return nil
+}
+
+func (opts AnteOptions) haltAllowed(msgType string) bool {
+	for _, allowed := range opts.HaltAllowedMsgTypes {
+		if msgType == allowed {
+			return true
+		}
+	}
+	return false
+}
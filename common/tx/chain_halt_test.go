@@ -0,0 +1,45 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerChainHalted(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, addr2 := testutils.PrivAndAddr()
+	sendMsg := newSendMsg(acc1.GetAddress(), addr2)
+	testMsg := newTestMsg(acc1.GetAddress())
+	sdkfees.RegisterCalculator(sendMsg.Type(), sdkfees.FreeFeeCalculator())
+
+	opts := tx.DefaultAnteOptions()
+	opts.ChainHalted = true
+	opts.HaltAllowedMsgTypes = []string{testMsg.Type()}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	// a normal tx is rejected
+	txn := newTestTx(ctx, []sdk.Msg{sendMsg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeChainHalted), res.Code)
+
+	// a whitelisted msg passes
+	txn = newTestTx(ctx, []sdk.Msg{testMsg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	// the whitelisted msg doesn't carry others through
+	txn = newTestTx(ctx, []sdk.Msg{testMsg, sendMsg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	_, res, abort = anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeChainHalted), res.Code)
+}
@@ -0,0 +1,26 @@
+package tx
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Clock provides the current time to the time based checks of the ante handler
+type Clock interface {
+	Now(ctx sdk.Context) time.Time
+}
+
+type blockTimeClock struct{}
+
+func (blockTimeClock) Now(ctx sdk.Context) time.Time {
+	return ctx.BlockHeader().Time
+}
+
+// clock returns the clock of the options, which reads the block time if none is set
+func (opts AnteOptions) clock() Clock {
+	if opts.Clock == nil {
+		return blockTimeClock{}
+	}
+	return opts.Clock
+}
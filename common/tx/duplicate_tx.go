@@ -0,0 +1,47 @@
+package tx
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the hashes of the txs which passed the ante handler recently in CheckTx, nil means disabled
+var duplicateTxCache *lru.Cache
+
+// InitDuplicateTxCache makes CheckTx reject the txs that passed the ante handler recently without
+// any further processing, whether they are pre-checked or not. Size 0 disables the cache.
+// It's only consulted in CheckTx, so it has no effect on consensus.
+func InitDuplicateTxCache(size int) {
+	if size <= 0 {
+		duplicateTxCache = nil
+		return
+	}
+
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	duplicateTxCache = cache
+}
+
+func isCheckMode(mode sdk.RunTxMode) bool {
+	return mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeCheckAfterPre
+}
+
+func checkDuplicateTx(txHash string, mode sdk.RunTxMode) sdk.Error {
+	if duplicateTxCache == nil || !isCheckMode(mode) || txHash == "" {
+		return nil
+	}
+	if duplicateTxCache.Contains(txHash) {
+		return ErrDuplicateTx("duplicate tx: " + txHash)
+	}
+	return nil
+}
+
+func addCheckedTx(txHash string, mode sdk.RunTxMode) {
+	if duplicateTxCache == nil || !isCheckMode(mode) || txHash == "" {
+		return
+	}
+	duplicateTxCache.Add(txHash, true)
+}
@@ -0,0 +1,55 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerDuplicateTx(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+
+	tx.InitDuplicateTxCache(10)
+	defer tx.InitDuplicateTxCache(0)
+
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, "tx1"), txn, sdk.RunTxModeCheck)
+
+	// the duplicate is rejected before any other check, even with an invalid signature
+	txn.Signatures[0].Signature = nil
+	_, res, abort := anteHandler(ctx.WithValue(baseapp.TxHashKey, "tx1"), txn, sdk.RunTxModeCheck)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeDuplicateTx), res.Code)
+	require.Contains(t, res.Log, "duplicate tx")
+
+	// also after the pre-check
+	_, res, abort = anteHandler(ctx.WithValue(baseapp.TxHashKey, "tx1").WithRunTxMode(sdk.RunTxModeCheckAfterPre), txn, sdk.RunTxModeCheckAfterPre)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeDuplicateTx), res.Code)
+
+	// a distinct tx proceeds
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, "tx2"), txn, sdk.RunTxModeCheck)
+
+	// the txs pre-checked are cached too
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{2})
+	checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, "tx3").WithRunTxMode(sdk.RunTxModeCheckAfterPre), txn, sdk.RunTxModeCheckAfterPre)
+	_, res, abort = anteHandler(ctx.WithValue(baseapp.TxHashKey, "tx3"), txn, sdk.RunTxModeCheck)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeDuplicateTx), res.Code)
+
+	// the cache is not consulted in DeliverTx
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{3})
+	checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, "tx2").WithRunTxMode(sdk.RunTxModeDeliver), txn, sdk.RunTxModeDeliver)
+}
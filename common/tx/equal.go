@@ -0,0 +1,51 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// StdTxEqual tells whether two txs are semantically equal, i.e. they have the same msgs, memo, source and data,
+// and the same set of signatures regardless of the order.
+// There is no fee in the tx, since the fees are calculated on chain.
+func StdTxEqual(a, b auth.StdTx) bool {
+	if a.Memo != b.Memo || a.Source != b.Source || !bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+
+	if len(a.Msgs) != len(b.Msgs) {
+		return false
+	}
+	for i := range a.Msgs {
+		if a.Msgs[i].Type() != b.Msgs[i].Type() || !bytes.Equal(a.Msgs[i].GetSignBytes(), b.Msgs[i].GetSignBytes()) {
+			return false
+		}
+	}
+
+	if len(a.Signatures) != len(b.Signatures) {
+		return false
+	}
+	sigsA, sigsB := sortedSigKeys(a.Signatures), sortedSigKeys(b.Signatures)
+	for i := range sigsA {
+		if sigsA[i] != sigsB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedSigKeys(sigs []auth.StdSignature) []string {
+	keys := make([]string, len(sigs))
+	for i, sig := range sigs {
+		var pubKey []byte
+		if sig.PubKey != nil {
+			pubKey = sig.PubKey.Bytes()
+		}
+		keys[i] = fmt.Sprintf("%X/%X/%d/%d", pubKey, sig.Signature, sig.AccountNumber, sig.Sequence)
+	}
+	sort.Strings(keys)
+	return keys
+}
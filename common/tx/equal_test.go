@@ -0,0 +1,37 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestStdTxEqual(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0})
+	require.True(t, tx.StdTxEqual(txn, txn))
+
+	// reordered signatures
+	reordered := auth.NewStdTx(msgs, []auth.StdSignature{txn.Signatures[1], txn.Signatures[0]}, txn.Memo, txn.Source, txn.Data)
+	require.True(t, tx.StdTxEqual(txn, reordered))
+
+	// different memo
+	withMemo := auth.NewStdTx(msgs, txn.Signatures, "memo", txn.Source, txn.Data)
+	require.False(t, tx.StdTxEqual(txn, withMemo))
+
+	// missing signature
+	missingSig := auth.NewStdTx(msgs, txn.Signatures[:1], txn.Memo, txn.Source, txn.Data)
+	require.False(t, tx.StdTxEqual(txn, missingSig))
+}
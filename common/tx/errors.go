@@ -0,0 +1,35 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 20
+
+	CodeTxInBlockedTimeWindow sdk.CodeType = 1
+	CodeTooManyAccounts       sdk.CodeType = 2
+	CodeChainHalted           sdk.CodeType = 3
+	CodeTooManyMsgTypes       sdk.CodeType = 4
+	CodeDuplicateTx           sdk.CodeType = 5
+)
+
+func ErrTxInBlockedTimeWindow(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeTxInBlockedTimeWindow, msg)
+}
+
+func ErrTooManyAccounts(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeTooManyAccounts, msg)
+}
+
+func ErrChainHalted(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeChainHalted, msg)
+}
+
+func ErrTooManyMsgTypes(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeTooManyMsgTypes, msg)
+}
+
+func ErrDuplicateTx(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeDuplicateTx, msg)
+}
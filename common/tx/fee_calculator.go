@@ -0,0 +1,94 @@
+package tx
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// ValuedMsg is a msg whose fee scales with the value it moves, in the smallest unit of the native token
+type ValuedMsg interface {
+	sdk.Msg
+	GetFeeBase() int64
+}
+
+// ProportionalFeeCalculator charges max(minFee, value*rateBps/10000) native tokens, where the value is
+// the fee base of a ValuedMsg and the proportional part is rounded down. Other msgs are charged minFee.
+func ProportionalFeeCalculator(rateBps int64, minFee int64, feeType sdk.FeeDistributeType) sdkfees.FeeCalculator {
+	if feeType == sdk.FeeFree {
+		return sdkfees.FreeFeeCalculator()
+	}
+	return func(msg sdk.Msg) sdk.Fee {
+		amount := minFee
+		if valuedMsg, ok := msg.(ValuedMsg); ok {
+			if proportional := proportionalFee(valuedMsg.GetFeeBase(), rateBps); proportional > amount {
+				amount = proportional
+			}
+		}
+		return sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeToken(), amount)}, feeType)
+	}
+}
+
+// value*rateBps/10000 rounded down, computed with big ints so that it doesn't overflow in the middle
+func proportionalFee(value, rateBps int64) int64 {
+	fee := new(big.Int).Mul(big.NewInt(value), big.NewInt(rateBps))
+	return fee.Quo(fee, big.NewInt(10000)).Int64()
+}
+
+// MaxFeeCalculator charges the greatest native token fee among the calculators, e.g. the greater of
+// a FixedFeeCalculator and a ProportionalFeeCalculator. The first one wins a tie.
+func MaxFeeCalculator(calculators ...sdkfees.FeeCalculator) sdkfees.FeeCalculator {
+	return func(msg sdk.Msg) sdk.Fee {
+		var maxFee sdk.Fee
+		native := types.NativeToken()
+		for i, calculator := range calculators {
+			fee := calculator(msg)
+			if i == 0 || fee.Tokens.AmountOf(native) > maxFee.Tokens.AmountOf(native) {
+				maxFee = fee
+			}
+		}
+		return maxFee
+	}
+}
+
+// SizeFeeCalculator charges perByte native tokens for each byte of the sign bytes of the msg,
+// so that the fee scales with the size of the msg.
+func SizeFeeCalculator(perByte int64, feeType sdk.FeeDistributeType) sdkfees.FeeCalculator {
+	if feeType == sdk.FeeFree {
+		return sdkfees.FreeFeeCalculator()
+	}
+	return func(msg sdk.Msg) sdk.Fee {
+		amount := perByte * int64(len(msg.GetSignBytes()))
+		return sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeToken(), amount)}, feeType)
+	}
+}
+
+// HeightAwareFeeCalculator calculates the fee of a msg by the height of the block it's included in,
+// e.g. for a fee schedule that changes at an upgrade height
+type HeightAwareFeeCalculator func(height int64, msg sdk.Msg) sdk.Fee
+
+// TieredFeeCalculator charges by the before calculator below switchHeight, and by the after one from switchHeight on.
+func TieredFeeCalculator(switchHeight int64, before, after sdkfees.FeeCalculator) HeightAwareFeeCalculator {
+	return func(height int64, msg sdk.Msg) sdk.Fee {
+		if height < switchHeight {
+			return before(msg)
+		}
+		return after(msg)
+	}
+}
+
+// the height aware calculators keyed by msg type, they take precedence over the ones registered in sdkfees
+var heightAwareCalculators = make(map[string]HeightAwareFeeCalculator)
+
+func RegisterHeightAwareCalculator(msgType string, calculator HeightAwareFeeCalculator) {
+	heightAwareCalculators[msgType] = calculator
+}
+
+func UnsetHeightAwareCalculators() {
+	for msgType := range heightAwareCalculators {
+		delete(heightAwareCalculators, msgType)
+	}
+}
@@ -0,0 +1,119 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+type valuedTestMsg struct {
+	*sdk.TestMsg
+	value int64
+}
+
+func (msg valuedTestMsg) GetFeeBase() int64 { return msg.value }
+
+func TestProportionalFeeCalculator(t *testing.T) {
+	calculator := tx.ProportionalFeeCalculator(30, 10, sdk.FeeForProposer)
+	addr := sdk.AccAddress([]byte("addr1"))
+	for _, c := range []struct {
+		msg sdk.Msg
+		fee int64
+	}{
+		// 12345*30/10000 = 37.035, rounded down
+		{valuedTestMsg{sdk.NewTestMsg(addr), 12345}, 37},
+		// 10000*30/10000 = 30, divisible
+		{valuedTestMsg{sdk.NewTestMsg(addr), 10000}, 30},
+		// 3000*30/10000 = 9, below the min fee
+		{valuedTestMsg{sdk.NewTestMsg(addr), 3000}, 10},
+		// not a valued msg
+		{sdk.NewTestMsg(addr), 10},
+	} {
+		require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, c.fee)}, sdk.FeeForProposer), calculator(c.msg))
+	}
+
+	require.Equal(t, sdk.FeeFree, tx.ProportionalFeeCalculator(30, 10, sdk.FeeFree)(sdk.NewTestMsg(addr)).Type)
+}
+
+func TestAnteHandlerProportionalFees(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	msg := valuedTestMsg{newTestMsg(acc1.GetAddress()), 12345}
+	sdkfees.RegisterCalculator(msg.Type(), tx.ProportionalFeeCalculator(30, 10, sdk.FeeForProposer))
+
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 63)})
+
+	msg.value = 100
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 53)})
+	sdkfees.Pool.Clear()
+	tx.FeePayers.Clear()
+}
+
+func TestMaxFeeCalculator(t *testing.T) {
+	// the greater of 10 and 1% of the transfer
+	calculator := tx.MaxFeeCalculator(
+		sdkfees.FixedFeeCalculator(10, sdk.FeeForAll),
+		tx.ProportionalFeeCalculator(100, 0, sdk.FeeForProposer))
+	addr := sdk.AccAddress([]byte("addr1"))
+
+	// the flat fee dominates a small transfer
+	fee := calculator(valuedTestMsg{sdk.NewTestMsg(addr), 500})
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForAll), fee)
+
+	// the percentage dominates a large transfer
+	fee = calculator(valuedTestMsg{sdk.NewTestMsg(addr), 5000})
+	require.Equal(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 50)}, sdk.FeeForProposer), fee)
+}
+
+func TestSizeFeeCalculator(t *testing.T) {
+	calculator := tx.SizeFeeCalculator(2, sdk.FeeForProposer)
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+
+	small := sdk.NewTestMsg(addr1)
+	large := sdk.NewTestMsg(addr1, addr2, addr3)
+	smallFee := calculator(small).Tokens.AmountOf(types.NativeTokenSymbol)
+	largeFee := calculator(large).Tokens.AmountOf(types.NativeTokenSymbol)
+	require.Equal(t, 2*int64(len(small.GetSignBytes())), smallFee)
+	require.Equal(t, 2*int64(len(large.GetSignBytes())), largeFee)
+	require.True(t, largeFee > smallFee)
+}
+
+func TestAnteHandlerTieredFees(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	msg := newTestMsg(acc1.GetAddress())
+	tx.RegisterHeightAwareCalculator(msg.Type(), tx.TieredFeeCalculator(100,
+		sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer),
+		sdkfees.FixedFeeCalculator(20, sdk.FeeForAll)))
+	defer tx.UnsetHeightAwareCalculators()
+
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx.WithBlockHeight(99), txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx.WithBlockHeight(100), txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 70)})
+
+	fee, err := tx.EstimateFee(ctx.WithBlockHeight(100), txn)
+	require.Nil(t, err)
+	require.Equal(t, sdk.FeeForAll, fee.Type)
+}
@@ -0,0 +1,54 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// FeeDenomValidator decides which denoms the fees can be charged in
+type FeeDenomValidator interface {
+	ValidateFeeDenom(denom string) error
+}
+
+// SymbolFeeDenomValidator accepts any well-formed token or mini token symbol, for the chains which charge fees
+// in other tokens than the native one
+type SymbolFeeDenomValidator struct{}
+
+func (SymbolFeeDenomValidator) ValidateFeeDenom(denom string) error {
+	err := types.ValidateTokenSymbol(denom)
+	if err != nil && !types.IsValidMiniTokenSymbol(denom) {
+		return err
+	}
+	return nil
+}
+
+// NativeFeeDenomValidator only accepts the native token, it's the default one
+type NativeFeeDenomValidator struct{}
+
+func (NativeFeeDenomValidator) ValidateFeeDenom(denom string) error {
+	if denom != types.NativeToken() {
+		return fmt.Errorf("only %s is accepted", types.NativeToken())
+	}
+	return nil
+}
+
+// feeDenomValidator returns the fee denom validator of the options, which only accepts the native token if none is set
+func (opts AnteOptions) feeDenomValidator() FeeDenomValidator {
+	if opts.FeeDenomValidator == nil {
+		return NativeFeeDenomValidator{}
+	}
+	return opts.FeeDenomValidator
+}
+
+// validateFeeDenoms checks the fee denoms with the fee denom validator
+func validateFeeDenoms(fee sdk.Fee, validator FeeDenomValidator) sdk.Error {
+	for _, coin := range fee.Tokens {
+		if err := validator.ValidateFeeDenom(coin.Denom); err != nil {
+			return sdk.ErrInvalidCoins(fmt.Sprintf("invalid fee denom %q: %s", coin.Denom, err.Error()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func feeCalculatorWithDenom(denom string) func(msg sdk.Msg) sdk.Fee {
+	return func(msg sdk.Msg) sdk.Fee {
+		return sdk.NewFee(sdk.Coins{sdk.NewCoin(denom, 10)}, sdk.FeeForProposer)
+	}
+}
+
+func TestAnteHandlerFeeDenoms(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	// empty denom
+	msg := newTestMsgWithFeeCalculator(feeCalculatorWithDenom(""), acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeInvalidCoins, sdk.RunTxModeDeliver)
+
+	// over-length denom
+	msg = newTestMsgWithFeeCalculator(feeCalculatorWithDenom(strings.Repeat("A", 9)+"-000"), acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeInvalidCoins, sdk.RunTxModeDeliver)
+
+	// a well-formed token other than the native one
+	msg = newTestMsgWithFeeCalculator(feeCalculatorWithDenom("XYZ-000"), acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeInvalidCoins, sdk.RunTxModeDeliver)
+
+	// valid denom
+	msg = newTestMsgWithFeeCalculator(feeCalculatorWithDenom(types.NativeTokenSymbol), acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+}
+
+func TestAnteHandlerSymbolFeeDenomValidator(t *testing.T) {
+	am, ctx, _ := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	opts := tx.DefaultAnteOptions()
+	opts.FeeDenomValidator = tx.SymbolFeeDenomValidator{}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	priv1, addr1 := testutils.PrivAndAddr()
+	acc1 := am.NewAccountWithAddress(ctx, addr1)
+	_ = acc1.SetCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100), sdk.NewCoin("XYZ-000", 100)})
+	am.SetAccount(ctx, acc1)
+
+	// a well-formed token is accepted once the validator is opted in
+	msg := newTestMsgWithFeeCalculator(feeCalculatorWithDenom("XYZ-000"), addr1)
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, addr1, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100), sdk.NewCoin("XYZ-000", 90)})
+
+	// a malformed one is still rejected
+	msg = newTestMsgWithFeeCalculator(feeCalculatorWithDenom(strings.Repeat("A", 9)+"-000"), addr1)
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeInvalidCoins, sdk.RunTxModeCheck)
+}
+
+func TestAnteHandlerCustomNativeToken(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	types.SetNativeToken("TBNB")
+	defer types.UnsetNativeToken()
+
+	priv1, addr1 := testutils.PrivAndAddr()
+	acc1 := am.NewAccountWithAddress(ctx, addr1)
+	_ = acc1.SetCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100), sdk.NewCoin("TBNB", 100)})
+	am.SetAccount(ctx, acc1)
+
+	// the tx calculators charge the custom native token
+	msg := newTestMsgWithFeeCalculator(tx.ProportionalFeeCalculator(0, 10, sdk.FeeForProposer), addr1)
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, addr1, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100), sdk.NewCoin("TBNB", 90)})
+
+	// and only it is accepted
+	msg = newTestMsgWithFeeCalculator(feeCalculatorWithDenom(types.NativeTokenSymbol), addr1)
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeInvalidCoins, sdk.RunTxModeCheck)
+}
@@ -0,0 +1,77 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// FeeDescription is the fee of a msg type and how it's distributed, e.g. to be shown by the front-ends
+type FeeDescription struct {
+	Fee          sdk.Coins `json:"fee"`
+	Distribution string    `json:"distribution"`
+}
+
+// FeeForMsgType returns the calculator the ante handler uses for the msg type at the height of the ctx,
+// with the fee params in its state
+func FeeForMsgType(ctx sdk.Context, msgType string) (sdkfees.FeeCalculator, bool) {
+	return FeeForMsgTypeWithOptions(ctx, msgType, DefaultAnteOptions())
+}
+
+// FeeForMsgTypeWithOptions returns the same as FeeForMsgType, but for an ante handler with the given options.
+func FeeForMsgTypeWithOptions(ctx sdk.Context, msgType string, opts AnteOptions) (sdkfees.FeeCalculator, bool) {
+	calculator := opts.Calculators.get(msgType, ctx.BlockHeight(), loadFeeParams(ctx))
+	return calculator, calculator != nil
+}
+
+// DescribeFees describes the fees of the msg types at the height of the ctx, with the fee params in its state.
+// The msg types without a calculator are left out. Without msg types, the ones registered in sdkfees or by
+// RegisterParamsCalculator are described. A fee which depends on the content of the msg is described as the fee of an empty msg.
+func DescribeFees(ctx sdk.Context, msgTypes ...string) map[string]FeeDescription {
+	return DescribeFeesWithOptions(ctx, DefaultAnteOptions(), msgTypes...)
+}
+
+// DescribeFeesWithOptions describes the same as DescribeFees, but for an ante handler with the given options.
+// Without msg types, the ones of the calculators of the options are described if they are set.
+func DescribeFeesWithOptions(ctx sdk.Context, opts AnteOptions, msgTypes ...string) map[string]FeeDescription {
+	if len(msgTypes) == 0 && opts.Calculators != nil {
+		for msgType := range opts.Calculators {
+			msgTypes = append(msgTypes, msgType)
+		}
+	} else if len(msgTypes) == 0 {
+		for msgType := range sdkfees.CalculatorsGen {
+			msgTypes = append(msgTypes, msgType)
+		}
+		for msgType := range paramsCalculators {
+			if _, ok := sdkfees.CalculatorsGen[msgType]; !ok {
+				msgTypes = append(msgTypes, msgType)
+			}
+		}
+	}
+
+	params := loadFeeParams(ctx)
+	descriptions := make(map[string]FeeDescription, len(msgTypes))
+	for _, msgType := range msgTypes {
+		fee, err := calculateFees(emptyMsg{msgType: msgType}, ctx.BlockHeight(), params, opts)
+		if err != nil {
+			continue
+		}
+		if fee.Type == sdk.FeeFree || fee.Tokens.IsZero() {
+			descriptions[msgType] = FeeDescription{Fee: sdk.Coins{}, Distribution: "free"}
+		} else {
+			descriptions[msgType] = FeeDescription{Fee: fee.Tokens.Sort(), Distribution: feeDistributionNames[fee.Type]}
+		}
+	}
+	return descriptions
+}
+
+// emptyMsg is a msg of the type without any content
+type emptyMsg struct {
+	msgType string
+}
+
+func (msg emptyMsg) Route() string                          { return msg.msgType }
+func (msg emptyMsg) Type() string                           { return msg.msgType }
+func (msg emptyMsg) ValidateBasic() sdk.Error               { return nil }
+func (msg emptyMsg) GetSignBytes() []byte                   { return nil }
+func (msg emptyMsg) GetSigners() []sdk.AccAddress           { return nil }
+func (msg emptyMsg) GetInvolvedAddresses() []sdk.AccAddress { return nil }
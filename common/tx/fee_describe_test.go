@@ -0,0 +1,36 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestDescribeFees(t *testing.T) {
+	sdkfees.UnsetAllCalculators()
+	defer sdkfees.UnsetAllCalculators()
+	sdkfees.RegisterCalculator("order", sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	sdkfees.RegisterCalculator("issue", sdkfees.FixedFeeCalculator(1000, sdk.FeeForAll))
+	sdkfees.RegisterCalculator("cancel", sdkfees.FreeFeeCalculator())
+	sdkfees.RegisterCalculator("send", tx.ProportionalFeeCalculator(30, 5, sdk.FeeForProposer))
+
+	_, ctx, _ := setup()
+	calculator, ok := tx.FeeForMsgType(ctx, "order")
+	require.True(t, ok)
+	require.Equal(t, int64(10), calculator(sdk.NewTestMsg()).Tokens.AmountOf(types.NativeTokenSymbol))
+	_, ok = tx.FeeForMsgType(ctx, "unknown")
+	require.False(t, ok)
+
+	require.Equal(t, map[string]tx.FeeDescription{
+		"order":  {Fee: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, Distribution: "proposer"},
+		"issue":  {Fee: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1000)}, Distribution: "all"},
+		"cancel": {Fee: sdk.Coins{}, Distribution: "free"},
+		"send":   {Fee: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 5)}, Distribution: "proposer"},
+	}, tx.DescribeFees(ctx, "order", "issue", "cancel", "send", "unknown"))
+}
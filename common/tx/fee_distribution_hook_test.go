@@ -0,0 +1,45 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerFeeDistributionHook(t *testing.T) {
+	am, ctx, _ := setup()
+	var total sdk.Coins
+	var modes []int8
+	opts := tx.DefaultAnteOptions()
+	opts.FeeDistributionHook = func(ctx sdk.Context, fee sdk.Coins, mode int8) {
+		total = total.Plus(fee)
+		modes = append(modes, mode)
+	}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForAll), acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, total)
+	require.Equal(t, []int8{int8(sdk.FeeForAll)}, modes)
+
+	// nothing is collected in CheckTx or for a free tx
+	msg = newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForAll), acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkCtx, _ := ctx.CacheContext()
+	checkValidTx(t, anteHandler, checkCtx.WithRunTxMode(sdk.RunTxModeCheck), txn, sdk.RunTxModeCheck)
+	msg = newTestMsgWithFeeCalculator(sdkfees.FreeFeeCalculator(), acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	require.Len(t, modes, 1)
+}
@@ -0,0 +1,53 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// EstimateBlockFees sums the fees the txs would pay at the height of the ctx with the registered fee calculators
+// and the fee params in its state, without running the txs. Like the ante handler, the fee of a tx is the total
+// of the fees of its msgs, and the txs with a msg without a calculator are skipped.
+func EstimateBlockFees(ctx sdk.Context, txs []auth.StdTx) sdk.Coins {
+	return EstimateBlockFeesWithOptions(ctx, txs, DefaultAnteOptions())
+}
+
+// EstimateBlockFeesWithOptions sums the same as EstimateBlockFees, but for an ante handler with the given options.
+func EstimateBlockFeesWithOptions(ctx sdk.Context, txs []auth.StdTx, opts AnteOptions) sdk.Coins {
+	params := loadFeeParams(ctx)
+	total := sdk.Coins{}
+	for _, tx := range txs {
+		fee, err := calculateTotalFee(tx.GetMsgs(), ctx.BlockHeight(), params, opts)
+		if err != nil || fee.Type == sdk.FeeFree {
+			continue
+		}
+		total = total.Plus(fee.Tokens)
+	}
+	return total
+}
+
+// EstimateFee returns the fee the tx would be charged by the ante handler, without verifying the signatures
+// or touching any account. The fee type tells how the fee would be distributed.
+func EstimateFee(ctx sdk.Context, tx auth.StdTx) (sdk.Fee, sdk.Error) {
+	return EstimateFeeWithOptions(ctx, tx, DefaultAnteOptions())
+}
+
+// EstimateFeeWithOptions returns the same as EstimateFee, but for an ante handler with the given options.
+func EstimateFeeWithOptions(ctx sdk.Context, tx auth.StdTx, opts AnteOptions) (sdk.Fee, sdk.Error) {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return sdk.Fee{}, sdk.ErrUnauthorized("no messages in transaction")
+	}
+	signers := tx.GetSigners()
+	if len(signers) == 0 {
+		return sdk.Fee{}, sdk.ErrUnauthorized("no signers")
+	}
+
+	// estimate the fee charged once the tx is broadcast, which is not waived like in simulation
+	fee, err := resolveFee(ctx, signers[0], msgs, tx.GetMemo(), sdk.RunTxModeCheck, opts)
+	if err != nil {
+		return sdk.Fee{}, err
+	}
+	fee.Tokens = fee.Tokens.Sort()
+	return fee, nil
+}
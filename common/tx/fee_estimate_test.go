@@ -0,0 +1,67 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestEstimateBlockFees(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, addr2 := testutils.PrivAndAddr()
+
+	sendMsg := newSendMsg(acc1.GetAddress(), addr2)
+	sdkfees.RegisterCalculator(sendMsg.Type(), sdkfees.FixedFeeCalculator(10, sdk.FeeForAll))
+	testMsg := sdk.NewTestMsg(acc1.GetAddress())
+	sdkfees.RegisterCalculator(testMsg.Type(), sdkfees.FixedFeeCalculator(3, sdk.FeeForProposer))
+
+	privs, accNums := []crypto.PrivKey{priv1}, []int64{0}
+	txs := []auth.StdTx{
+		newTestTx(ctx, []sdk.Msg{sendMsg}, privs, accNums, []int64{0}),
+		newTestTx(ctx, []sdk.Msg{testMsg}, privs, accNums, []int64{1}),
+		newTestTx(ctx, []sdk.Msg{sendMsg}, privs, accNums, []int64{2}),
+	}
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 23)}, tx.EstimateBlockFees(ctx, txs))
+
+	// free msgs don't add to the total
+	sdkfees.RegisterCalculator(testMsg.Type(), sdkfees.FreeFeeCalculator())
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 20)}, tx.EstimateBlockFees(ctx, txs))
+}
+
+func TestEstimateFee(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	privs, accNums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+
+	for _, c := range []struct {
+		calculator sdkfees.FeeCalculator
+		expected   sdk.Fee
+	}{
+		{sdkfees.FreeFeeCalculator(), sdk.NewFee(sdk.Coins{}, sdk.FeeFree)},
+		{sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForProposer)},
+		{sdkfees.FixedFeeCalculator(20, sdk.FeeForAll), sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 20)}, sdk.FeeForAll)},
+	} {
+		msg := newTestMsgWithFeeCalculator(c.calculator, acc1.GetAddress())
+		fee, err := tx.EstimateFee(ctx, newTestTx(ctx, []sdk.Msg{msg}, privs, accNums, seqs))
+		require.Nil(t, err)
+		require.Equal(t, c.expected.Type, fee.Type)
+		require.True(t, c.expected.Tokens.IsEqual(fee.Tokens), "expected %s, got %s", c.expected.Tokens, fee.Tokens)
+	}
+	// nothing is charged
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+
+	sdkfees.UnsetAllCalculators()
+	_, err := tx.EstimateFee(ctx, newTestTx(ctx, []sdk.Msg{sdk.NewTestMsg(acc1.GetAddress())}, privs, accNums, seqs))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,16 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// checkDistributeTypeOverrides panics if a distribute type is overridden with anything but FeeForProposer or FeeForAll
+func checkDistributeTypeOverrides(overrides map[string]sdk.FeeDistributeType) {
+	for msgType, dt := range overrides {
+		if dt != sdk.FeeForProposer && dt != sdk.FeeForAll {
+			panic(fmt.Errorf("distribute type of msg type %s can only be overridden with FeeForProposer or FeeForAll", msgType))
+		}
+	}
+}
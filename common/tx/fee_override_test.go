@@ -0,0 +1,30 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerDistributeTypeOverride(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	opts := tx.DefaultAnteOptions()
+	opts.DistributeTypeOverrides = map[string]sdk.FeeDistributeType{sdk.NewTestMsg().Type(): sdk.FeeForAll}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	// the amount charged is kept, but the fee is distributed to all
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, priv1, acc1.GetAddress(), sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+	checkFee(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForAll))
+
+	opts.DistributeTypeOverrides = map[string]sdk.FeeDistributeType{sdk.NewTestMsg().Type(): sdk.FeeFree}
+	require.Panics(t, func() { tx.NewAnteHandlerWithOptions(am, opts) })
+}
@@ -0,0 +1,98 @@
+package tx
+
+import (
+	"fmt"
+	"math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/wire"
+)
+
+// the number of the recent fees kept for each account
+const maxRecentFees = 10
+
+var (
+	feesPaidKeyPrefix   = []byte("feesPaid:")
+	recentFeesKeyPrefix = []byte("recentFees:")
+)
+
+// FeeRecord is a fee paid by an account at the height
+type FeeRecord struct {
+	Height int64     `json:"height"`
+	Amount sdk.Coins `json:"amount"`
+}
+
+// FeePaidKeeper keeps a running total of the fees each account has paid, and the most recent fees.
+type FeePaidKeeper struct {
+	key sdk.StoreKey
+	cdc *wire.Codec
+}
+
+func NewFeePaidKeeper(cdc *wire.Codec, key sdk.StoreKey) FeePaidKeeper {
+	return FeePaidKeeper{
+		key: key,
+		cdc: cdc,
+	}
+}
+
+func feesPaidKey(addr sdk.AccAddress) []byte {
+	return append(feesPaidKeyPrefix, addr.Bytes()...)
+}
+
+func recentFeesKey(addr sdk.AccAddress) []byte {
+	return append(recentFeesKeyPrefix, addr.Bytes()...)
+}
+
+// GetFeesPaid returns the total fees paid by the account so far
+func (k FeePaidKeeper) GetFeesPaid(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	bz := ctx.KVStore(k.key).Get(feesPaidKey(addr))
+	if bz == nil {
+		return sdk.Coins{}
+	}
+
+	var coins sdk.Coins
+	k.cdc.MustUnmarshalBinaryBare(bz, &coins)
+	return coins
+}
+
+// AddFeesPaid adds the fee to the total paid by the account, it panics if the total of any denom overflows
+func (k FeePaidKeeper) AddFeesPaid(ctx sdk.Context, addr sdk.AccAddress, fee sdk.Coins) sdk.Coins {
+	paid := k.GetFeesPaid(ctx, addr)
+	for _, coin := range fee {
+		if total := paid.AmountOf(coin.Denom); coin.Amount > 0 && total > math.MaxInt64-coin.Amount {
+			panic(fmt.Errorf("fee overflow: %s paid %d%s, adding %s", addr, total, coin.Denom, coin))
+		}
+	}
+	total := paid.Plus(fee)
+	ctx.KVStore(k.key).Set(feesPaidKey(addr), k.cdc.MustMarshalBinaryBare(total))
+	k.addRecentFee(ctx, addr, fee)
+	return total
+}
+
+// GetRecentFees returns the last fees paid by the account, from the oldest to the latest
+func (k FeePaidKeeper) GetRecentFees(ctx sdk.Context, addr sdk.AccAddress) []FeeRecord {
+	bz := ctx.KVStore(k.key).Get(recentFeesKey(addr))
+	if bz == nil {
+		return nil
+	}
+
+	var records []FeeRecord
+	k.cdc.MustUnmarshalBinaryBare(bz, &records)
+	return records
+}
+
+func (k FeePaidKeeper) addRecentFee(ctx sdk.Context, addr sdk.AccAddress, fee sdk.Coins) {
+	records := append(k.GetRecentFees(ctx, addr), FeeRecord{Height: ctx.BlockHeight(), Amount: fee})
+	if len(records) > maxRecentFees {
+		records = records[len(records)-maxRecentFees:]
+	}
+	ctx.KVStore(k.key).Set(recentFeesKey(addr), k.cdc.MustMarshalBinaryBare(records))
+}
+
+// the keeper used by the ante handler to track fees paid, nil means tracking is disabled
+var feePaidKeeper *FeePaidKeeper
+
+func SetFeePaidKeeper(k *FeePaidKeeper) {
+	feePaidKeeper = k
+}
@@ -0,0 +1,100 @@
+package tx_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAnteHandlerFeesPaid(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	feePaidKeeper := tx.NewFeePaidKeeper(cdc, capKey2)
+	tx.SetFeePaidKeeper(&feePaidKeeper)
+	defer tx.SetFeePaidKeeper(nil)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 100)
+	require.Equal(t, sdk.Coins{}, feePaidKeeper.GetFeesPaid(ctx, acc1.GetAddress()))
+
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, priv1, acc1.GetAddress(),
+		sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer),
+		sdkfees.FreeFeeCalculator(),
+		sdkfees.FixedFeeCalculator(20, sdk.FeeForAll),
+		sdkfees.FixedFeeCalculator(5, sdk.FeeForProposer))
+	sdkfees.Pool.Clear()
+
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 65)})
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 35)}, feePaidKeeper.GetFeesPaid(ctx, acc1.GetAddress()))
+	require.Equal(t, sdk.Coins{}, feePaidKeeper.GetFeesPaid(ctx, acc2.GetAddress()))
+}
+
+func TestAnteHandlerRecentFees(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	feePaidKeeper := tx.NewFeePaidKeeper(cdc, capKey2)
+	tx.SetFeePaidKeeper(&feePaidKeeper)
+	defer tx.SetFeePaidKeeper(nil)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 1000)
+	require.Empty(t, feePaidKeeper.GetRecentFees(ctx, acc1.GetAddress()))
+
+	for i := int64(0); i < 12; i++ {
+		msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(i+1, sdk.FeeForProposer), acc1.GetAddress())
+		txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{i})
+		checkValidTx(t, anteHandler, ctx.WithBlockHeight(i+1), txn, sdk.RunTxModeDeliver)
+	}
+	sdkfees.Pool.Clear()
+	tx.FeePayers.Clear()
+
+	// only the last 10 fees are kept
+	records := feePaidKeeper.GetRecentFees(ctx, acc1.GetAddress())
+	require.Len(t, records, 10)
+	for i, record := range records {
+		require.Equal(t, int64(i+3), record.Height)
+		require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, int64(i+3))}, record.Amount)
+	}
+}
+
+func TestFeePaidKeeperOverflow(t *testing.T) {
+	ms, _, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	feePaidKeeper := tx.NewFeePaidKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	_, addr := testutils.PrivAndAddr()
+
+	feePaidKeeper.AddFeesPaid(ctx, addr, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, math.MaxInt64-10)})
+	total := feePaidKeeper.AddFeesPaid(ctx, addr, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)})
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, math.MaxInt64)}, total)
+
+	require.PanicsWithValue(t, fmt.Errorf("fee overflow: %s paid %d%s, adding %s", addr, int64(math.MaxInt64),
+		types.NativeTokenSymbol, sdk.NewCoin(types.NativeTokenSymbol, 1)), func() {
+		feePaidKeeper.AddFeesPaid(ctx, addr, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1)})
+	})
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, math.MaxInt64)}, feePaidKeeper.GetFeesPaid(ctx, addr))
+}
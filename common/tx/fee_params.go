@@ -0,0 +1,100 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// DefaultFeeParamspace is the name of the params subspace of the fee params
+const DefaultFeeParamspace = "txfee"
+
+var paramStoreKeyFees = []byte("fees")
+
+// FeeParam is the fee amount of a param key, in the native token
+type FeeParam struct {
+	Key    string `json:"key"`
+	Amount int64  `json:"amount"`
+}
+
+// FeeParams are the fee amounts used by the calculators created by ParamFeeCalculator, so that they can be
+// changed by e.g. governance rather than a binary upgrade
+type FeeParams struct {
+	Fees []FeeParam `json:"fees"`
+}
+
+// AmountOf returns the fee amount of the param key, 0 if it's not set
+func (p FeeParams) AmountOf(key string) int64 {
+	for _, fee := range p.Fees {
+		if fee.Key == key {
+			return fee.Amount
+		}
+	}
+	return 0
+}
+
+// FeeParamsKeeper reads and writes the fee params in a params subspace
+type FeeParamsKeeper struct {
+	paramSpace params.Subspace
+}
+
+func NewFeeParamsKeeper(paramSpace params.Subspace) FeeParamsKeeper {
+	return FeeParamsKeeper{
+		paramSpace: paramSpace.WithTypeTable(params.NewTypeTable(paramStoreKeyFees, FeeParams{})),
+	}
+}
+
+func (k FeeParamsKeeper) GetFeeParams(ctx sdk.Context) FeeParams {
+	var feeParams FeeParams
+	k.paramSpace.GetIfExists(ctx, paramStoreKeyFees, &feeParams)
+	return feeParams
+}
+
+func (k FeeParamsKeeper) SetFeeParams(ctx sdk.Context, feeParams FeeParams) {
+	k.paramSpace.Set(ctx, paramStoreKeyFees, feeParams)
+}
+
+// the keeper the fee params are loaded from when the fees of a tx are calculated, nil means there are no fee params
+var feeParamsKeeper *FeeParamsKeeper
+
+func SetFeeParamsKeeper(k *FeeParamsKeeper) {
+	feeParamsKeeper = k
+}
+
+// loadFeeParams returns the fee params in the state of the ctx
+func loadFeeParams(ctx sdk.Context) FeeParams {
+	if feeParamsKeeper == nil {
+		return FeeParams{}
+	}
+	return feeParamsKeeper.GetFeeParams(ctx)
+}
+
+// ParamsFeeCalculator calculates the fee of a msg by the fee params, which are loaded from the keeper set by
+// SetFeeParamsKeeper for every tx
+type ParamsFeeCalculator func(params FeeParams, msg sdk.Msg) sdk.Fee
+
+// ParamFeeCalculator returns a calculator charging the fee amount of the param key in the fee params.
+// A fee amount that is not positive is free.
+func ParamFeeCalculator(paramKey string, feeType sdk.FeeDistributeType) ParamsFeeCalculator {
+	return func(params FeeParams, msg sdk.Msg) sdk.Fee {
+		amount := params.AmountOf(paramKey)
+		if amount <= 0 || feeType == sdk.FeeFree {
+			return sdk.NewFee(sdk.Coins{}, sdk.FeeFree)
+		}
+		return sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeToken(), amount)}, feeType)
+	}
+}
+
+// the calculators using the fee params keyed by msg type, they take precedence over the ones registered in sdkfees
+var paramsCalculators = make(map[string]ParamsFeeCalculator)
+
+func RegisterParamsCalculator(msgType string, calculator ParamsFeeCalculator) {
+	paramsCalculators[msgType] = calculator
+}
+
+func UnsetParamsCalculators() {
+	for msgType := range paramsCalculators {
+		delete(paramsCalculators, msgType)
+	}
+}
@@ -0,0 +1,73 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAnteHandlerParamFeeCalculator(t *testing.T) {
+	db := dbm.NewMemDB()
+	capKey := sdk.NewKVStoreKey("capkey")
+	paramsKey := sdk.NewKVStoreKey("params")
+	tparamsKey := sdk.NewTransientStoreKey("tparams")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tparamsKey, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	anteHandler := tx.NewAnteHandler(am)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeCheck, log.NewNopLogger()).
+		WithAccountCache(getAccountCache(cdc, ms, capKey))
+
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tparamsKey)
+	feeParamsKeeper := tx.NewFeeParamsKeeper(paramsKeeper.Subspace(tx.DefaultFeeParamspace))
+	tx.SetFeeParamsKeeper(&feeParamsKeeper)
+	defer tx.SetFeeParamsKeeper(nil)
+	feeParamsKeeper.SetFeeParams(ctx, tx.FeeParams{Fees: []tx.FeeParam{{Key: "testMsgFee", Amount: 10}}})
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := sdk.NewTestMsg(acc1.GetAddress())
+	tx.RegisterParamsCalculator(msg.Type(), tx.ParamFeeCalculator("testMsgFee", sdk.FeeForProposer))
+	defer tx.UnsetParamsCalculators()
+
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+
+	// the new fee is charged without changing the calculator
+	feeParamsKeeper.SetFeeParams(ctx, tx.FeeParams{Fees: []tx.FeeParam{{Key: "testMsgFee", Amount: 25}}})
+	require.Equal(t, int64(25), feeParamsKeeper.GetFeeParams(ctx).AmountOf("testMsgFee"))
+	// and described without running a tx first
+	require.Equal(t, map[string]tx.FeeDescription{
+		msg.Type(): {Fee: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 25)}, Distribution: "proposer"},
+	}, tx.DescribeFees(ctx, msg.Type()))
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 65)})
+
+	// without the param the msg is free
+	feeParamsKeeper.SetFeeParams(ctx, tx.FeeParams{})
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{2})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 65)})
+}
@@ -0,0 +1,32 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeePayerMsg is implemented by the msgs which designate another signer than the first one to pay the fee,
+// e.g. a relayer paying for a meta tx. The payer should be a part of the sign bytes of the msg.
+type FeePayerMsg interface {
+	sdk.Msg
+	GetFeePayer() sdk.AccAddress
+}
+
+// getDesignatedFeePayer returns the signer account designated by the msg to pay the fee, nil if there is none.
+// The designated payer must have signed the tx.
+func getDesignatedFeePayer(signerAccs []sdk.Account, msg sdk.Msg) (sdk.Account, sdk.Error) {
+	feePayerMsg, ok := msg.(FeePayerMsg)
+	if !ok || len(feePayerMsg.GetFeePayer()) == 0 {
+		return nil, nil
+	}
+
+	payer := feePayerMsg.GetFeePayer()
+	for _, acc := range signerAccs {
+		if bytes.Equal(acc.GetAddress(), payer) {
+			return acc, nil
+		}
+	}
+	return nil, sdk.ErrUnauthorized(fmt.Sprintf("fee payer %s has not signed the tx", payer))
+}
@@ -0,0 +1,70 @@
+package tx_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/common/upgrade"
+	"github.com/bnb-chain/node/plugins/account"
+)
+
+type feePayerTestMsg struct {
+	*sdk.TestMsg
+	payer sdk.AccAddress
+}
+
+func (msg feePayerTestMsg) GetFeePayer() sdk.AccAddress { return msg.payer }
+
+func TestAnteHandlerDesignatedFeePayer(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	calculator := sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer)
+
+	// acc2 pays for the msg of acc1
+	msg := feePayerTestMsg{newTestMsgWithFeeCalculator(calculator, acc1.GetAddress(), acc2.GetAddress()), acc2.GetAddress()}
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+	checkBalance(t, am, ctx, acc2.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+
+	// the payer has not signed
+	msg = feePayerTestMsg{newTestMsgWithFeeCalculator(calculator, acc1.GetAddress()), acc2.GetAddress()}
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeCheck)
+}
+
+func TestAnteHandlerSetAccountFlagsFeePayer(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	msg := account.NewSetAccountFlagsMsg(acc1.GetAddress(), 1)
+	sdkfees.UnsetAllCalculators()
+	sdkfees.RegisterCalculator(msg.Type(), sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+
+	// the sign bytes of the msgs without a fee payer are unchanged
+	require.NotContains(t, string(msg.GetSignBytes()), "fee_payer")
+	msg.FeePayer = acc2.GetAddress()
+	require.Error(t, msg.ValidateBasic())
+	upgrade.Mgr.AddUpgradeHeight(upgrade.DesignatedFeePayer, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.DesignatedFeePayer, math.MaxInt64)
+	require.NoError(t, msg.ValidateBasic())
+	require.Equal(t, []sdk.AccAddress{acc1.GetAddress(), acc2.GetAddress()}, msg.GetSigners())
+
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+	checkBalance(t, am, ctx, acc2.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+}
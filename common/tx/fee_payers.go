@@ -0,0 +1,45 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type FeePayment struct {
+	Payer sdk.AccAddress
+	Fee   sdk.Coins
+}
+
+// block level record of the fees paid by each tx, so that the fee can be refunded if it can't be distributed.
+type feePayerPool struct {
+	payments  map[string]FeePayment // TxHash -> payment
+	committed []FeePayment
+}
+
+var FeePayers = newFeePayerPool()
+
+func newFeePayerPool() *feePayerPool {
+	return &feePayerPool{
+		payments: map[string]FeePayment{},
+	}
+}
+
+func (p *feePayerPool) AddPayment(txHash string, payer sdk.AccAddress, fee sdk.Coins) {
+	p.payments[txHash] = FeePayment{Payer: payer, Fee: fee}
+}
+
+// Commit marks the payment of the tx as committed, txs without fee payment are ignored.
+func (p *feePayerPool) Commit(txHash string) {
+	if payment, ok := p.payments[txHash]; ok {
+		p.committed = append(p.committed, payment)
+	}
+}
+
+// Committed returns the payments of the committed txs in the order they are committed.
+func (p *feePayerPool) Committed() []FeePayment {
+	return p.committed
+}
+
+func (p *feePayerPool) Clear() {
+	p.payments = map[string]FeePayment{}
+	p.committed = nil
+}
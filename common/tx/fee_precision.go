@@ -0,0 +1,41 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// checkDenomPrecisions panics if a precision is out of [0, types.TokenDecimals]
+func checkDenomPrecisions(denomPrecisions map[string]int8) {
+	for denom, decimals := range denomPrecisions {
+		if decimals < 0 || decimals > types.TokenDecimals {
+			panic(fmt.Errorf("precision of %s should be in [0, %d]", denom, types.TokenDecimals))
+		}
+	}
+}
+
+// RoundFee rounds the fee amounts half up to the smallest units of their denoms, i.e. 10^-decimals with the
+// decimals in denomPrecisions, the coins which are rounded to zero are dropped.
+func RoundFee(coins sdk.Coins, denomPrecisions map[string]int8) sdk.Coins {
+	if len(denomPrecisions) == 0 {
+		return coins
+	}
+
+	rounded := sdk.Coins{}
+	for _, coin := range coins {
+		if decimals, ok := denomPrecisions[coin.Denom]; ok {
+			unit := int64(1)
+			for i := decimals; i < types.TokenDecimals; i++ {
+				unit *= 10
+			}
+			coin.Amount = (coin.Amount + unit/2) / unit * unit
+		}
+		if coin.Amount != 0 {
+			rounded = append(rounded, coin)
+		}
+	}
+	return rounded
+}
@@ -0,0 +1,51 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestRoundFee(t *testing.T) {
+	precisions := map[string]int8{"AAA-000": 8, "BBB-000": 2}
+
+	// 8 decimals, the amounts are already in the smallest unit
+	require.Equal(t, sdk.Coins{sdk.NewCoin("AAA-000", 12345678)}, tx.RoundFee(sdk.Coins{sdk.NewCoin("AAA-000", 12345678)}, precisions))
+
+	// 2 decimals, the smallest unit is 1e6
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BBB-000", 1000000)}, tx.RoundFee(sdk.Coins{sdk.NewCoin("BBB-000", 1234567)}, precisions))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BBB-000", 2000000)}, tx.RoundFee(sdk.Coins{sdk.NewCoin("BBB-000", 1500000)}, precisions))
+	require.Equal(t, sdk.Coins{}, tx.RoundFee(sdk.Coins{sdk.NewCoin("BBB-000", 499999)}, precisions))
+
+	// denoms without a precision are kept
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1234567)}, tx.RoundFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1234567)}, precisions))
+
+	// nothing is rounded without precisions
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BBB-000", 1234567)}, tx.RoundFee(sdk.Coins{sdk.NewCoin("BBB-000", 1234567)}, nil))
+}
+
+func TestAnteHandlerRoundFee(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 1e8)
+	opts := tx.DefaultAnteOptions()
+	opts.DenomPrecisions = map[string]int8{types.NativeTokenSymbol: 2}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, priv1, acc1.GetAddress(), sdkfees.FixedFeeCalculator(1234567, sdk.FeeForProposer))
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1e8-1e6)})
+	checkFee(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 1e6)}, sdk.FeeForProposer))
+}
+
+func TestAnteHandlerInvalidDenomPrecision(t *testing.T) {
+	am, _, _ := setup()
+	opts := tx.DefaultAnteOptions()
+	opts.DenomPrecisions = map[string]int8{"CCC-000": 9}
+	require.Panics(t, func() { tx.NewAnteHandlerWithOptions(am, opts) })
+}
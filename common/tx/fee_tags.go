@@ -0,0 +1,27 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the tags of the fee charged by the ante handler
+const (
+	TagFee             = "fee"
+	TagFeeDistribution = "fee_distribution"
+)
+
+var feeDistributionNames = map[sdk.FeeDistributeType]string{
+	sdk.FeeForProposer: "proposer",
+	sdk.FeeForAll:      "all",
+}
+
+// feeTags returns the tags of the fee deducted and how it's distributed, no tags for a free fee
+func feeTags(fee sdk.Fee) sdk.Tags {
+	if fee.Type == sdk.FeeFree || fee.Tokens.IsZero() {
+		return nil
+	}
+	return sdk.NewTags(
+		TagFee, []byte(fee.Tokens.String()),
+		TagFeeDistribution, []byte(feeDistributionNames[fee.Type]),
+	)
+}
@@ -0,0 +1,41 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerFeeTags(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	defer sdkfees.Pool.Clear()
+	defer tx.FeePayers.Clear()
+
+	msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.Equal(t, sdk.NewTags(
+		tx.TagPubKeySet, []byte(acc1.GetAddress().String()),
+		tx.TagPubKeysSet, []byte(acc1.GetAddress().String()),
+		tx.TagFee, []byte(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}.String()),
+		tx.TagFeeDistribution, []byte("proposer"),
+	), res.Tags)
+
+	// no tags for a free msg
+	msg = newTestMsg(acc1.GetAddress())
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	_, res, abort = anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.Empty(t, res.Tags)
+}
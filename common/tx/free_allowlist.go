@@ -0,0 +1,31 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// the msg types which are allowed to be free, keyed by msg type
+var freeMsgTypes = make(map[string]bool)
+
+// RegisterFreeCalculator makes the msg type free and allows it to be free when AnteOptions.EnforceFreeAllowlist is set.
+func RegisterFreeCalculator(msgType string) {
+	sdkfees.RegisterCalculator(msgType, sdkfees.FreeFeeCalculator())
+	freeMsgTypes[msgType] = true
+}
+
+func UnsetFreeMsgTypes() {
+	for msgType := range freeMsgTypes {
+		delete(freeMsgTypes, msgType)
+	}
+}
+
+func checkFreeAllowed(msg sdk.Msg, fee sdk.Fee, enforced bool) sdk.Error {
+	if !enforced || freeMsgTypes[msg.Type()] {
+		return nil
+	}
+	if fee.Type == sdk.FeeFree || fee.Tokens.IsZero() {
+		return sdk.NewError(sdk.CodespaceRoot, sdk.CodeInsufficientFee, "msg type %s is not allowed to be free", msg.Type())
+	}
+	return nil
+}
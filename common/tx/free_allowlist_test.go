@@ -0,0 +1,43 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerFreeAllowlist(t *testing.T) {
+	am, ctx, _ := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := newTypedTestMsgs(acc1.GetAddress(), "allowed", "misconfigured")
+	privs, accNums := []crypto.PrivKey{priv1}, []int64{0}
+
+	opts := tx.DefaultAnteOptions()
+	opts.EnforceFreeAllowlist = true
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	defer tx.UnsetFreeMsgTypes()
+	tx.RegisterFreeCalculator("allowed")
+
+	txn := newTestTx(ctx, msgs[:1], privs, accNums, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+
+	// registered free without the allowlist
+	txn = newTestTx(ctx, msgs[1:], privs, accNums, []int64{1})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeInsufficientFee, sdk.RunTxModeCheck)
+
+	// fine once it's charged
+	sdkfees.RegisterCalculator("misconfigured", sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	require.Equal(t, int64(90), am.GetAccount(ctx, acc1.GetAddress()).GetCoins().AmountOf(types.NativeTokenSymbol))
+}
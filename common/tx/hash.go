@@ -0,0 +1,52 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"github.com/tendermint/tendermint/libs/common"
+)
+
+type HashAlgo int8
+
+const (
+	HashSHA256 HashAlgo = iota
+	HashKeccak256
+)
+
+func (algo HashAlgo) String() string {
+	switch algo {
+	case HashSHA256:
+		return "SHA-256"
+	case HashKeccak256:
+		return "Keccak-256"
+	default:
+		return fmt.Sprintf("HashAlgo(%d)", int8(algo))
+	}
+}
+
+var txIDAlgo = HashSHA256
+
+// SetTxIDAlgorithm sets the algorithm used by TxID, SHA-256 by default.
+func SetTxIDAlgorithm(algo HashAlgo) {
+	if algo != HashSHA256 && algo != HashKeccak256 {
+		panic(fmt.Errorf("unsupported tx id algorithm %s", algo))
+	}
+	txIDAlgo = algo
+}
+
+// TxID returns the hex encoded hash of the tx bytes with the configured algorithm, e.g. for an integrator
+// identifying the txs by their Keccak-256 hashes.
+//
+// NOTE: it's not the tx hash of the chain. Tendermint and BaseApp always hash the tx with SHA-256,
+// so the hash in the context (i.e. baseapp.TxHashKey) and the ones queried by it are not calculated by this function.
+func TxID(txBytes []byte) string {
+	switch txIDAlgo {
+	case HashKeccak256:
+		return common.HexBytes(crypto.Keccak256(txBytes)).String()
+	default:
+		return common.HexBytes(tmhash.Sum(txBytes)).String()
+	}
+}
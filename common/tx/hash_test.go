@@ -0,0 +1,26 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestTxIDAlgorithm(t *testing.T) {
+	txBytes := []byte("some tx bytes")
+	defer tx.SetTxIDAlgorithm(tx.HashSHA256)
+
+	sha256Hash := tx.TxID(txBytes)
+	require.Equal(t, "C4385A213F723BE2C6C5A2A618B20010D3903A20F5DAFB7688ABF740DE5CA5E6", sha256Hash)
+	require.Equal(t, sha256Hash, tx.TxID(txBytes))
+
+	tx.SetTxIDAlgorithm(tx.HashKeccak256)
+	keccakHash := tx.TxID(txBytes)
+	require.Len(t, keccakHash, 64)
+	require.Equal(t, keccakHash, tx.TxID(txBytes))
+	require.NotEqual(t, sha256Hash, keccakHash)
+
+	require.Panics(t, func() { tx.SetTxIDAlgorithm(tx.HashAlgo(10)) })
+}
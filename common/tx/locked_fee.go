@@ -0,0 +1,50 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+// OpenOrderChecker tells whether the account has open orders on the DEX
+type OpenOrderChecker func(addr sdk.AccAddress) bool
+
+var openOrderChecker OpenOrderChecker
+
+// SetOpenOrderChecker sets the checker telling AnteOptions.PayFeesFromLockedCoins which accounts have open orders
+func SetOpenOrderChecker(checker OpenOrderChecker) {
+	openOrderChecker = checker
+}
+
+// unlockCoinsForFee moves the shortfall of the fee from the locked coins to the free coins of the account.
+// The account is left untouched if it has open orders, or if the locked coins can't cover the shortfall either.
+func unlockCoinsForFee(acc sdk.Account, fee sdk.Fee) {
+	namedAcc, ok := acc.(types.NamedAccount)
+	if !ok {
+		return
+	}
+
+	coins := acc.GetCoins()
+	shortfall := sdk.Coins{}
+	for _, coin := range fee.Tokens {
+		if missing := coin.Amount - coins.AmountOf(coin.Denom); missing > 0 {
+			shortfall = append(shortfall, sdk.NewCoin(coin.Denom, missing))
+		}
+	}
+	if shortfall.IsZero() {
+		return
+	}
+	if openOrderChecker == nil || openOrderChecker(acc.GetAddress()) {
+		return
+	}
+
+	locked := namedAcc.GetLockedCoins().Minus(shortfall.Sort())
+	if !locked.IsNotNegative() {
+		return
+	}
+	namedAcc.SetLockedCoins(locked)
+	if err := acc.SetCoins(coins.Plus(shortfall)); err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+}
@@ -0,0 +1,128 @@
+package tx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAnteHandlerPayFeesFromLockedCoins(t *testing.T) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeCheck, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	newLockedAccount := func(locked int64) (crypto.PrivKey, sdk.AccAddress) {
+		priv, acc := testutils.NewAccount(ctx, am, 0)
+		acc.(types.NamedAccount).SetLockedCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, locked)})
+		am.SetAccount(ctx, acc)
+		return priv, acc.GetAddress()
+	}
+	priv1, addr1 := newLockedAccount(100)
+	priv2, addr2 := newLockedAccount(5)
+	msg1 := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), addr1)
+	tx1 := newTestTx(ctx, []sdk.Msg{msg1}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	msg2 := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), addr2)
+	tx2 := newTestTx(ctx, []sdk.Msg{msg2}, []crypto.PrivKey{priv2}, []int64{1}, []int64{0})
+
+	// disabled by default
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, tx1, sdk.CodeInsufficientFunds, sdk.RunTxModeCheck)
+
+	opts := tx.DefaultAnteOptions()
+	opts.PayFeesFromLockedCoins = true
+	anteHandler = tx.NewAnteHandlerWithOptions(am, opts)
+
+	// nothing is unlocked without telling the account has no open orders
+	cacheCtx, _ = ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, tx1, sdk.CodeInsufficientFunds, sdk.RunTxModeCheck)
+
+	// the locked coins of the open orders are kept
+	hasOpenOrders := true
+	tx.SetOpenOrderChecker(func(sdk.AccAddress) bool { return hasOpenOrders })
+	defer tx.SetOpenOrderChecker(nil)
+	cacheCtx, _ = ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, tx1, sdk.CodeInsufficientFunds, sdk.RunTxModeCheck)
+
+	hasOpenOrders = false
+	checkValidTx(t, anteHandler, ctx, tx1, sdk.RunTxModeCheck)
+	acc1 := am.GetAccount(ctx, addr1).(types.NamedAccount)
+	require.True(t, acc1.GetCoins().IsZero())
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)}, acc1.GetLockedCoins())
+
+	// the locked coins are not enough either
+	cacheCtx, _ = ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, tx2, sdk.CodeInsufficientFunds, sdk.RunTxModeCheck)
+	acc2 := am.GetAccount(cacheCtx, addr2).(types.NamedAccount)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 5)}, acc2.GetLockedCoins())
+}
+
+func TestAnteHandlerFrozenCoinsNotSpendable(t *testing.T) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeCheck, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	require.NoError(t, types.FreezeCoins(acc1.(types.NamedAccount), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 95)}))
+	am.SetAccount(ctx, acc1)
+
+	msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeInsufficientFunds, sdk.RunTxModeCheck)
+
+	require.NoError(t, types.UnfreezeCoins(acc1.(types.NamedAccount), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 5)}))
+	am.SetAccount(ctx, acc1)
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	require.True(t, am.GetAccount(ctx, acc1.GetAddress()).GetCoins().IsZero())
+}
+
+func TestAnteHandlerUnlocksMaturedCoins(t *testing.T) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	header := abci.Header{ChainID: "mychainid", Height: 1, Time: time.Unix(1000, 0)}
+	ctx := sdk.NewContext(ms, header, sdk.RunTxModeCheck, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	namedAcc := acc1.(types.NamedAccount)
+	require.NoError(t, types.VestCoins(namedAcc, []types.LockEntry{
+		{UnlockTime: 1000, Coins: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 30)}},
+		{UnlockTime: 2000, Coins: sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 70)}},
+	}))
+	am.SetAccount(ctx, acc1)
+
+	msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+
+	acc := am.GetAccount(ctx, acc1.GetAddress()).(types.NamedAccount)
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 20)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 70)}, acc.GetVestingCoins())
+	require.True(t, acc.GetLockedCoins().IsZero())
+	require.Len(t, acc.GetLockSchedule(), 1)
+}
@@ -0,0 +1,29 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// the max memo characters of the txs paid by the allowlisted accounts
+const maxLargeMemoCharacters = 512
+
+// the accounts allowed to pay for txs with large memos, keyed by address bytes
+var largeMemoAllowlist = make(map[string]bool)
+
+// SetLargeMemoAllowlist lets the txs paid by the accounts carry memos of up to 512 characters, the memos of
+// the other txs are still capped by the ante handler's MaxMemoBytes. The fee payer is the first signer of the tx.
+func SetLargeMemoAllowlist(addrs []sdk.AccAddress) {
+	largeMemoAllowlist = make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		largeMemoAllowlist[string(addr.Bytes())] = true
+	}
+}
+
+func maxMemoCharactersOf(tx auth.StdTx, maxMemo int) int {
+	signers := tx.GetSigners()
+	if len(signers) > 0 && largeMemoAllowlist[string(signers[0].Bytes())] && maxLargeMemoCharacters > maxMemo {
+		return maxLargeMemoCharacters
+	}
+	return maxMemo
+}
@@ -0,0 +1,40 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerLargeMemoAllowlist(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+
+	tx.SetLargeMemoAllowlist([]sdk.AccAddress{acc1.GetAddress()})
+	defer tx.SetLargeMemoAllowlist(nil)
+
+	memo := strings.Repeat("m", 300)
+
+	// allowlisted account sends a large memo
+	msg := newTestMsg(acc1.GetAddress())
+	txn := newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0}, memo)
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	// still capped by the larger limit
+	memo = strings.Repeat("m", 513)
+	txn = newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1}, memo)
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeMemoTooLarge, sdk.RunTxModeDeliver)
+
+	// non-allowlisted account is capped
+	memo = strings.Repeat("m", 300)
+	msg = newTestMsg(acc2.GetAddress())
+	txn = newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv2}, []int64{1}, []int64{0}, memo)
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeMemoTooLarge, sdk.RunTxModeDeliver)
+}
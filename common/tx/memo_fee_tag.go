@@ -0,0 +1,40 @@
+package tx
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// a memo starting with a fee tag, e.g. "fee:all", overrides the distribute type of the tx's fee
+const memoFeeTagPrefix = "fee:"
+
+var memoFeeTags = map[string]sdk.FeeDistributeType{
+	"all":      sdk.FeeForAll,
+	"proposer": sdk.FeeForProposer,
+}
+
+// the fee payers allowed to override the distribute type with memo tags, keyed by address bytes
+var memoFeeTagAllowlist = make(map[string]bool)
+
+// SetMemoFeeTagAllowlist lets the txs paid by the accounts override the distribute type of their fees
+// with a tag as the first word of the memo: "fee:all" or "fee:proposer". The tags of the other txs,
+// as well as the unknown tags, are ignored.
+func SetMemoFeeTagAllowlist(addrs []sdk.AccAddress) {
+	memoFeeTagAllowlist = make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		memoFeeTagAllowlist[string(addr.Bytes())] = true
+	}
+}
+
+func memoDistributeType(memo string, payer sdk.AccAddress) (sdk.FeeDistributeType, bool) {
+	if !memoFeeTagAllowlist[string(payer.Bytes())] {
+		return 0, false
+	}
+	fields := strings.Fields(memo)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], memoFeeTagPrefix) {
+		return 0, false
+	}
+	dt, ok := memoFeeTags[strings.TrimPrefix(fields[0], memoFeeTagPrefix)]
+	return dt, ok
+}
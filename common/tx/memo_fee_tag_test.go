@@ -0,0 +1,43 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerMemoFeeTag(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+
+	tx.SetMemoFeeTagAllowlist([]sdk.AccAddress{acc1.GetAddress()})
+	defer tx.SetMemoFeeTagAllowlist(nil)
+	defer sdkfees.Pool.Clear()
+	defer tx.FeePayers.Clear()
+
+	runTx := func(priv crypto.PrivKey, addr sdk.AccAddress, accNum, seq int64, memo, txHash string) sdk.FeeDistributeType {
+		msg := newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), addr)
+		txn := newTestTxWithMemo(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv}, []int64{accNum}, []int64{seq}, memo)
+		checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, txHash), txn, sdk.RunTxModeDeliver)
+		return sdkfees.Pool.GetFee(txHash).Type
+	}
+
+	// the tag of an allowlisted payer overrides the distribute type
+	require.Equal(t, sdk.FeeForAll, runTx(priv1, acc1.GetAddress(), 0, 0, "fee:all batch 1", "MEMOTAG1"))
+	// unknown tags are ignored
+	require.Equal(t, sdk.FeeForProposer, runTx(priv1, acc1.GetAddress(), 0, 1, "fee:nobody", "MEMOTAG2"))
+	// the tag has to be the first word
+	require.Equal(t, sdk.FeeForProposer, runTx(priv1, acc1.GetAddress(), 0, 2, "batch fee:all", "MEMOTAG3"))
+	// the tags of the other payers are ignored
+	require.Equal(t, sdk.FeeForProposer, runTx(priv2, acc2.GetAddress(), 1, 0, "fee:all", "MEMOTAG4"))
+}
@@ -0,0 +1,29 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the max number of distinct msg types in a tx, 0 means no limit
+var maxDistinctMsgTypes int
+
+// SetMaxDistinctMsgTypes makes the ante handler reject the txs carrying more than n distinct msg types, 0 disables the limit.
+func SetMaxDistinctMsgTypes(n int) {
+	maxDistinctMsgTypes = n
+}
+
+func checkDistinctMsgTypes(msgs []sdk.Msg) sdk.Error {
+	if maxDistinctMsgTypes <= 0 {
+		return nil
+	}
+	msgTypes := make(map[string]bool)
+	for _, msg := range msgs {
+		msgTypes[msg.Type()] = true
+	}
+	if len(msgTypes) > maxDistinctMsgTypes {
+		return ErrTooManyMsgTypes(fmt.Sprintf("tx has %d distinct msg types, the limit is %d", len(msgTypes), maxDistinctMsgTypes))
+	}
+	return nil
+}
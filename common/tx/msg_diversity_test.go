@@ -0,0 +1,52 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+type typedTestMsg struct {
+	*sdk.TestMsg
+	msgType string
+}
+
+func (msg typedTestMsg) Type() string { return msg.msgType }
+
+func newTypedTestMsgs(addr sdk.AccAddress, msgTypes ...string) []sdk.Msg {
+	sdkfees.UnsetAllCalculators()
+	msgs := make([]sdk.Msg, len(msgTypes))
+	for i, msgType := range msgTypes {
+		msgs[i] = typedTestMsg{TestMsg: sdk.NewTestMsg(addr), msgType: msgType}
+		sdkfees.RegisterCalculator(msgType, sdkfees.FreeFeeCalculator())
+	}
+	return msgs
+}
+
+func TestAnteHandlerMaxDistinctMsgTypes(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	tx.SetMaxDistinctMsgTypes(2)
+	defer tx.SetMaxDistinctMsgTypes(0)
+
+	// at the limit, repeated msg types count once
+	msgs := newTypedTestMsgs(acc1.GetAddress(), "a", "b", "a", "b")
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	// over the limit
+	msgs = newTypedTestMsgs(acc1.GetAddress(), "a", "b", "c")
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeTooManyMsgTypes), res.Code)
+}
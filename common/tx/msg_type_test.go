@@ -0,0 +1,27 @@
+package tx_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+)
+
+func TestAnteHandlerUnknownMsgType(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, addr2 := testutils.PrivAndAddr()
+
+	// newTestMsg unsets the calculator of the send msg, so the send msg is unknown
+	sendMsg := newSendMsg(acc1.GetAddress(), addr2)
+	testMsg := newTestMsg(acc1.GetAddress())
+	txn := newTestTx(ctx, []sdk.Msg{testMsg, sendMsg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkInvalidTx(t, anteHandler, ctx, txn, sdk.CodeUnknownRequest, sdk.RunTxModeDeliver)
+
+	sdkfees.RegisterCalculator(sendMsg.Type(), sdkfees.FreeFeeCalculator())
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
@@ -0,0 +1,46 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+// BaseApp rejects the txs with more than one msg, the ante handler still charges them in full when run directly
+func TestAnteHandlerMultiMsgFees(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := newTypedTestMsgs(acc1.GetAddress(), "free", "proposer", "all")
+	sdkfees.RegisterCalculator("proposer", sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	sdkfees.RegisterCalculator("all", sdkfees.FixedFeeCalculator(20, sdk.FeeForAll))
+	privs, accNums := []crypto.PrivKey{priv1}, []int64{0}
+
+	for i, c := range []struct {
+		msgs     []sdk.Msg
+		expected sdk.Fee
+	}{
+		{msgs[:1], sdk.NewFee(sdk.Coins{}, sdk.FeeFree)},
+		{msgs[:2], sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForProposer)},
+		{msgs, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 30)}, sdk.FeeForAll)},
+		{[]sdk.Msg{msgs[2], msgs[1]}, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 30)}, sdk.FeeForAll)},
+	} {
+		txn := newTestTx(ctx, c.msgs, privs, accNums, []int64{int64(i)})
+		fee, err := tx.EstimateFee(ctx, txn)
+		require.Nil(t, err)
+		require.Equal(t, c.expected, fee)
+
+		before := am.GetAccount(ctx, acc1.GetAddress()).GetCoins()
+		checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+		checkBalance(t, am, ctx, acc1.GetAddress(), before.Minus(c.expected.Tokens))
+	}
+}
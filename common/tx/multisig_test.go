@@ -0,0 +1,61 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/bnb-chain/node/common/types"
+)
+
+func newMultisigTx(ctx sdk.Context, msg sdk.Msg, multisigKey crypto.PubKey, privs []crypto.PrivKey, signers []int, accNum, seq int64) sdk.Tx {
+	pubKeys := make([]crypto.PubKey, len(privs))
+	for i, priv := range privs {
+		pubKeys[i] = priv.PubKey()
+	}
+	signBytes := auth.StdSignBytes(ctx.ChainID(), accNum, seq, []sdk.Msg{msg}, "", 0, nil)
+	multisignature := multisig.NewMultisig(len(privs))
+	for _, i := range signers {
+		sig, err := privs[i].Sign(signBytes)
+		if err != nil {
+			panic(err)
+		}
+		if err := multisignature.AddSignatureFromPubKey(sig, pubKeys[i], pubKeys); err != nil {
+			panic(err)
+		}
+	}
+	sig := auth.StdSignature{PubKey: multisigKey, Signature: multisignature.Marshal(), AccountNumber: accNum, Sequence: seq}
+	return auth.NewStdTx([]sdk.Msg{msg}, []auth.StdSignature{sig}, "", 0, nil)
+}
+
+func TestAnteHandlerMultisig(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()})
+	addr := sdk.AccAddress(multisigKey.Address())
+	acc := am.NewAccountWithAddress(ctx, addr)
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)}))
+	am.SetAccount(ctx, acc)
+	msg := newTestMsg(addr)
+
+	// 1 of 3 is not enough
+	txn := newMultisigTx(ctx, msg, multisigKey, privs, []int{1}, acc.GetAccountNumber(), 0)
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+
+	// 2 of 3
+	txn = newMultisigTx(ctx, msg, multisigKey, privs, []int{0, 2}, acc.GetAccountNumber(), 0)
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, multisigKey.Equals(am.GetAccount(ctx, addr).GetPubKey()))
+
+	// another 2 of 3 after the pubkey is set
+	txn = newMultisigTx(ctx, msg, multisigKey, privs, []int{1, 2}, acc.GetAccountNumber(), 1)
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
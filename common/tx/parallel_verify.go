@@ -0,0 +1,51 @@
+package tx
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// VerifySignaturesParallel verifies the signatures of the txs with the pubkeys they carry, spread over the
+// workers. Nothing depending on the state, e.g. the pubkeys and sequences of the accounts, is checked, that is
+// left to the ante handler, which then finds the verified signatures in the sig cache.
+// The i-th error is of the i-th tx, nil if the tx is valid.
+func VerifySignaturesParallel(ctx sdk.Context, txs []auth.StdTx, workers int) []error {
+	if workers < 1 {
+		workers = 1
+	}
+	chainID := ctx.ChainID()
+	errs := make([]error, len(txs))
+	indexes := make(chan int, len(txs))
+	for i := range txs {
+		indexes <- i
+	}
+	close(indexes)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = verifyTxSignatures(chainID, txs[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// verifyTxSignatures checks the tx like the default ante handler does, so the signatures it verifies are
+// found in the sig cache by that handler.
+func verifyTxSignatures(chainID string, stdTx auth.StdTx) error {
+	opts := DefaultAnteOptions()
+	if err := validateBasic(stdTx, opts); err != nil {
+		return err
+	}
+	if err := verifySignatures(chainID, "", stdTx, opts.sigVerifier()); err != nil {
+		return err
+	}
+	return nil
+}
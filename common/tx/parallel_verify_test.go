@@ -0,0 +1,91 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+// newSingleSignerTxs returns n txs of different signers, every third one with an invalid signature
+func newSingleSignerTxs(ctx sdk.Context, n int) []auth.StdTx {
+	txs := make([]auth.StdTx, n)
+	for i := range txs {
+		priv, addr := testutils.PrivAndAddr()
+		txs[i] = newTestTx(ctx, []sdk.Msg{newTestMsg(addr)}, []crypto.PrivKey{priv}, []int64{int64(i)}, []int64{0})
+		if i%3 == 2 {
+			sig := append([]byte{}, txs[i].Signatures[0].Signature...)
+			sig[0] ^= 0xff
+			txs[i].Signatures[0].Signature = sig
+		}
+	}
+	return txs
+}
+
+func TestVerifySignaturesParallel(t *testing.T) {
+	_, ctx, _ := setup()
+	txs := newSingleSignerTxs(ctx, 20)
+	// no signature at all
+	txs[4].Signatures = nil
+	defer tx.InitSigCache(30000)
+
+	tx.InitSigCache(1)
+	serial := tx.VerifySignaturesParallel(ctx, txs, 1)
+	tx.InitSigCache(1)
+	parallel := tx.VerifySignaturesParallel(ctx, txs, 8)
+	require.Equal(t, errStrings(serial), errStrings(parallel))
+
+	for i, err := range parallel {
+		switch {
+		case i == 4:
+			require.Equal(t, sdk.CodeUnauthorized, err.(sdk.Error).Code(), "tx %d", i)
+		case i%3 == 2:
+			require.Error(t, err, "tx %d", i)
+			require.Contains(t, err.Error(), "signature verification failed")
+		default:
+			require.NoError(t, err, "tx %d", i)
+		}
+	}
+
+	// more workers than txs
+	require.Equal(t, errStrings(serial[:2]), errStrings(tx.VerifySignaturesParallel(ctx, txs[:2], 8)))
+}
+
+func errStrings(errs []error) []string {
+	strs := make([]string, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			strs[i] = err.Error()
+		}
+	}
+	return strs
+}
+
+func benchmarkVerifySignatures(b *testing.B, workers int) {
+	_, ctx, _ := setup()
+	txs := newSingleSignerTxs(ctx, 100)
+	defer tx.InitSigCache(30000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		tx.InitSigCache(1)
+		b.StartTimer()
+		tx.VerifySignaturesParallel(ctx, txs, workers)
+	}
+}
+
+func BenchmarkVerifySignaturesSerial(b *testing.B) {
+	benchmarkVerifySignatures(b, 1)
+}
+
+func BenchmarkVerifySignaturesParallel(b *testing.B) {
+	benchmarkVerifySignatures(b, 8)
+}
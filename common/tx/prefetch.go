@@ -0,0 +1,24 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// PrefetchAccounts loads the accounts into the account cache of ctx ahead of the ante processing,
+// e.g. for the signers of a block's txs right after they are decoded. Missing accounts are ignored.
+func PrefetchAccounts(ctx sdk.Context, addrs []sdk.AccAddress) {
+	accountCache := ctx.AccountCache()
+	for _, addr := range addrs {
+		accountCache.GetAccount(addr)
+	}
+}
+
+// GetAccounts returns the accounts of the addresses in the same order, nil for the missing ones.
+func GetAccounts(ctx sdk.Context, am auth.AccountKeeper, addrs []sdk.AccAddress) []sdk.Account {
+	accs := make([]sdk.Account, len(addrs))
+	for i, addr := range addrs {
+		accs[i] = am.GetAccount(ctx, addr)
+	}
+	return accs
+}
@@ -0,0 +1,118 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/wire"
+)
+
+const (
+	benchSigners = 3
+	benchTxs     = 50
+)
+
+func benchmarkAnteHandlerBlock(b *testing.B, prefetch bool) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	anteHandler := tx.NewAnteHandler(am)
+
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	// each tx is signed by its own group of accounts
+	txs := make([]sdk.Tx, benchTxs)
+	var signers []sdk.AccAddress
+	for i := range txs {
+		privs := make([]crypto.PrivKey, benchSigners)
+		addrs := make([]sdk.AccAddress, benchSigners)
+		accNums := make([]int64, benchSigners)
+		for j := range privs {
+			priv, acc := testutils.NewAccount(ctx, am, 100)
+			privs[j], addrs[j], accNums[j] = priv, acc.GetAddress(), acc.GetAccountNumber()
+		}
+		signers = append(signers, addrs...)
+		txs[i] = newTestTx(ctx, []sdk.Msg{newTestMsg(addrs...)}, privs, accNums, make([]int64, benchSigners))
+	}
+	accountCache.Write()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		// start from a cold cache on every run
+		ctx := ctx.WithAccountCache(getAccountCache(cdc, ms, capKey))
+		if prefetch {
+			tx.PrefetchAccounts(ctx, signers)
+		}
+		b.StartTimer()
+
+		for _, txn := range txs {
+			if _, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver); abort {
+				b.Fatal(res.Log)
+			}
+		}
+	}
+}
+
+func BenchmarkAnteHandlerColdAccounts(b *testing.B) {
+	benchmarkAnteHandlerBlock(b, false)
+}
+
+func BenchmarkAnteHandlerPrefetchedAccounts(b *testing.B) {
+	benchmarkAnteHandlerBlock(b, true)
+}
+
+func TestGetAccounts(t *testing.T) {
+	am, ctx, _ := setup()
+	_, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 200)
+	_, addr3 := testutils.PrivAndAddr()
+
+	accs := tx.GetAccounts(ctx, am, []sdk.AccAddress{acc2.GetAddress(), addr3, acc1.GetAddress(), acc2.GetAddress()})
+	require.Len(t, accs, 4)
+	require.Equal(t, acc2.GetAddress(), accs[0].GetAddress())
+	require.Nil(t, accs[1])
+	require.Equal(t, acc1.GetAddress(), accs[2].GetAddress())
+	require.Equal(t, acc2.GetAddress(), accs[3].GetAddress())
+	require.Empty(t, tx.GetAccounts(ctx, am, nil))
+}
+
+func benchmarkGetAccounts(b *testing.B, batch bool) {
+	am, ctx, _ := setup()
+	addrs := make([]sdk.AccAddress, benchSigners)
+	for i := range addrs {
+		_, acc := testutils.NewAccount(ctx, am, 100)
+		addrs[i] = acc.GetAddress()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if batch {
+			tx.GetAccounts(ctx, am, addrs)
+		} else {
+			for _, addr := range addrs {
+				am.GetAccount(ctx, addr)
+			}
+		}
+	}
+}
+
+func BenchmarkGetAccountsOneByOne(b *testing.B) {
+	benchmarkGetAccounts(b, false)
+}
+
+func BenchmarkGetAccountsBatch(b *testing.B) {
+	benchmarkGetAccounts(b, true)
+}
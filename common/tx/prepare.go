@@ -0,0 +1,34 @@
+package tx
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// PrepareTx builds the sign doc of each signer for a tx over the msgs, with the signer's current
+// account number and sequence looked up from the account keeper. The docs are in the order of signers.
+func PrepareTx(ctx sdk.Context, am auth.AccountKeeper, msgs []sdk.Msg, memo string, source int64, signers []sdk.AccAddress) ([]auth.StdSignDoc, sdk.Error) {
+	msgsBytes := make([]json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
+	}
+
+	docs := make([]auth.StdSignDoc, 0, len(signers))
+	for _, signer := range signers {
+		acc := am.GetAccount(ctx, signer)
+		if acc == nil {
+			return nil, sdk.ErrUnknownAddress(signer.String())
+		}
+		docs = append(docs, auth.StdSignDoc{
+			AccountNumber: acc.GetAccountNumber(),
+			ChainID:       ctx.ChainID(),
+			Memo:          memo,
+			Msgs:          msgsBytes,
+			Sequence:      acc.GetSequence(),
+			Source:        source,
+		})
+	}
+	return docs, nil
+}
@@ -0,0 +1,43 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestPrepareTx(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	// move the sequence of acc1 forward
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	signers := []sdk.AccAddress{acc1.GetAddress(), acc2.GetAddress()}
+	docs, err := tx.PrepareTx(ctx, am, []sdk.Msg{newTestMsg(signers...)}, "memo", 0, signers)
+	require.Nil(t, err)
+	require.Len(t, docs, 2)
+	for i, signer := range signers {
+		acc := am.GetAccount(ctx, signer)
+		require.Equal(t, acc.GetAccountNumber(), docs[i].AccountNumber)
+		require.Equal(t, acc.GetSequence(), docs[i].Sequence)
+		require.Equal(t, ctx.ChainID(), docs[i].ChainID)
+		require.Equal(t, "memo", docs[i].Memo)
+	}
+	require.Equal(t, int64(1), docs[0].Sequence)
+
+	// unknown signer
+	_, addr3 := testutils.PrivAndAddr()
+	_, err = tx.PrepareTx(ctx, am, []sdk.Msg{msg}, "", 0, []sdk.AccAddress{addr3})
+	require.Equal(t, sdk.CodeUnknownAddress, err.Code())
+}
@@ -0,0 +1,70 @@
+package tx
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+type pubKeyMigration struct {
+	ChainID       string         `json:"chain_id"`
+	Address       sdk.AccAddress `json:"address"`
+	NewPubKey     []byte         `json:"new_pub_key"`
+	AccountNumber int64          `json:"account_number"`
+	Sequence      int64          `json:"sequence"`
+}
+
+// PubKeyMigrationSignBytes returns the bytes the current key of the account signs to migrate to the new pubkey
+func PubKeyMigrationSignBytes(chainID string, addr sdk.AccAddress, newPubKey crypto.PubKey, accNum, seq int64) []byte {
+	bz, err := json.Marshal(pubKeyMigration{
+		ChainID:       chainID,
+		Address:       addr,
+		NewPubKey:     newPubKey.Bytes(),
+		AccountNumber: accNum,
+		Sequence:      seq,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(bz)
+}
+
+// MigratePubKey replaces the pubkey of the account with newPubKey if the proof is signed by the current pubkey
+// over PubKeyMigrationSignBytes. The sequence of the account is incremented like by a tx, so the proof can't be
+// replayed. The address of the account is kept, so it's no longer the address of its pubkey.
+func MigratePubKey(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, newPubKey crypto.PubKey, proof []byte) sdk.Error {
+	return MigratePubKeyWithOptions(ctx, am, addr, newPubKey, proof, DefaultAnteOptions())
+}
+
+// MigratePubKeyWithOptions is MigratePubKey incrementing the sequence by the SequenceIncrement of the options
+func MigratePubKeyWithOptions(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, newPubKey crypto.PubKey,
+	proof []byte, opts AnteOptions) sdk.Error {
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.ErrUnknownAddress(addr.String())
+	}
+	if newPubKey == nil {
+		return sdk.ErrInvalidPubKey("new pubkey should not be nil")
+	}
+
+	pubKey := acc.GetPubKey()
+	if pubKey == nil {
+		return sdk.ErrInvalidPubKey("account has no pubkey to migrate from")
+	}
+	signBytes := PubKeyMigrationSignBytes(ctx.ChainID(), addr, newPubKey, acc.GetAccountNumber(), acc.GetSequence())
+	if !pubKey.VerifyBytes(signBytes, proof) {
+		return sdk.ErrUnauthorized("pubkey migration proof verification failed")
+	}
+
+	if err := acc.SetPubKey(newPubKey); err != nil {
+		return sdk.ErrInternal("setting PubKey on account")
+	}
+	if err := acc.SetSequence(acc.GetSequence() + opts.sequenceIncrement()); err != nil {
+		return sdk.ErrInternal("setting sequence on account")
+	}
+	am.SetAccount(ctx, acc)
+	return nil
+}
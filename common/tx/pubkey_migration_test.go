@@ -0,0 +1,70 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestMigratePubKey(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	addr1 := acc1.GetAddress()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+
+	// set the pubkey with the first tx
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+
+	newPriv, _ := testutils.PrivAndAddr()
+	signBytes := tx.PubKeyMigrationSignBytes(ctx.ChainID(), addr1, newPriv.PubKey(), 0, 1)
+
+	// invalid proof signed by the new key
+	proof, err := newPriv.Sign(signBytes)
+	require.NoError(t, err)
+	sdkErr := tx.MigratePubKey(ctx, am, addr1, newPriv.PubKey(), proof)
+	require.Equal(t, sdk.CodeUnauthorized, sdkErr.Code())
+	require.Equal(t, priv1.PubKey(), am.GetAccount(ctx, addr1).GetPubKey())
+
+	// valid proof signed by the current key
+	proof, err = priv1.Sign(signBytes)
+	require.NoError(t, err)
+	require.Nil(t, tx.MigratePubKey(ctx, am, addr1, newPriv.PubKey(), proof))
+	acc := am.GetAccount(ctx, addr1)
+	require.Equal(t, newPriv.PubKey(), acc.GetPubKey())
+	require.Equal(t, int64(2), acc.GetSequence())
+
+	// the proof can't be replayed
+	require.Nil(t, tx.MigratePubKey(ctx, am, addr1, priv1.PubKey(), mustSign(t, newPriv,
+		tx.PubKeyMigrationSignBytes(ctx.ChainID(), addr1, priv1.PubKey(), 0, 2))))
+	sdkErr = tx.MigratePubKey(ctx, am, addr1, newPriv.PubKey(), proof)
+	require.Equal(t, sdk.CodeUnauthorized, sdkErr.Code())
+	require.Nil(t, tx.MigratePubKey(ctx, am, addr1, newPriv.PubKey(), mustSign(t, priv1,
+		tx.PubKeyMigrationSignBytes(ctx.ChainID(), addr1, newPriv.PubKey(), 0, 3))))
+
+	// the new key signs the next tx, also with the strict signer order
+	opts := tx.DefaultAnteOptions()
+	opts.StrictSignerOrder = true
+	strict := tx.NewAnteHandlerWithOptions(am, opts)
+	txn = newTestTx(ctx, msgs, []crypto.PrivKey{newPriv}, []int64{0}, []int64{4})
+	sig, ok := tx.GetSignatureForSigner(txn, addr1)
+	require.True(t, ok)
+	require.Equal(t, newPriv.PubKey(), sig.PubKey)
+	cacheCtx, _ := ctx.CacheContext()
+	checkValidTx(t, strict, cacheCtx.WithValue(baseapp.TxHashKey, "migrated"), txn, sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx.WithValue(baseapp.TxHashKey, "migrated"), txn, sdk.RunTxModeDeliver)
+}
+
+func mustSign(t *testing.T, priv crypto.PrivKey, signBytes []byte) []byte {
+	sig, err := priv.Sign(signBytes)
+	require.NoError(t, err)
+	return sig
+}
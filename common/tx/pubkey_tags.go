@@ -0,0 +1,29 @@
+package tx
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// TagPubKeySet is the tag of the address whose pubkey is set by the ante handler, i.e. the first tx of the account.
+	// It's added to the tx result by the handlers of the router returned by NewAnteTagsRouter.
+	TagPubKeySet = "account.pubkey_set"
+	// TagPubKeysSet is the tag of all the addresses whose pubkeys are set by a tx, comma separated, added the same way
+	TagPubKeysSet = "account.pubkeys_set"
+)
+
+// pubKeySetTags returns a TagPubKeySet for each address, and a TagPubKeysSet of them all
+func pubKeySetTags(addrs []sdk.AccAddress) sdk.Tags {
+	if len(addrs) == 0 {
+		return nil
+	}
+	tags := make(sdk.Tags, 0, len(addrs)+1)
+	strs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strs[i] = addr.String()
+		tags = tags.AppendTag(TagPubKeySet, []byte(strs[i]))
+	}
+	return tags.AppendTag(TagPubKeysSet, []byte(strings.Join(strs, ",")))
+}
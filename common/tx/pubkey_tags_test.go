@@ -0,0 +1,63 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerPubKeySetTag(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+	privs, accNums := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}
+
+	// the first tx sets the pubkeys of both signers
+	txn := newTestTx(ctx, msgs, privs, accNums, []int64{0, 0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.Equal(t, sdk.NewTags(
+		tx.TagPubKeySet, []byte(acc1.GetAddress().String()),
+		tx.TagPubKeySet, []byte(acc2.GetAddress().String()),
+		tx.TagPubKeysSet, []byte(acc1.GetAddress().String()+","+acc2.GetAddress().String()),
+	), res.Tags)
+
+	// not again once the pubkeys are set
+	txn = newTestTx(ctx, msgs, privs, accNums, []int64{1, 1})
+	_, res, abort = anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+	require.Empty(t, res.Tags)
+}
+
+func TestAnteHandlerPubKeysSetTag(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	priv3, acc3 := testutils.NewAccount(ctx, am, 100)
+	// acc2 has sent a tx before
+	_ = acc2.SetPubKey(priv2.PubKey())
+	am.SetAccount(ctx, acc2)
+
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress(), acc3.GetAddress())}
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv2, priv3}, []int64{0, 1, 2}, []int64{0, 0, 0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.False(t, abort)
+
+	var set []string
+	for _, tag := range res.Tags {
+		if string(tag.Key) == tx.TagPubKeysSet {
+			set = append(set, strings.Split(string(tag.Value), ",")...)
+		}
+	}
+	require.Equal(t, []string{acc1.GetAddress().String(), acc3.GetAddress().String()}, set)
+	require.NotContains(t, set, acc2.GetAddress().String())
+}
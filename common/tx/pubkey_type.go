@@ -0,0 +1,63 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// checkPubKeyType makes sure the pubkey is an ed25519 or secp256k1 key, or a threshold multisig of them
+func checkPubKeyType(pubKey crypto.PubKey) sdk.Error {
+	switch pk := pubKey.(type) {
+	case ed25519.PubKeyEd25519:
+		return nil
+	case secp256k1.PubKeySecp256k1:
+		// the address is derived from the compressed form, and an uncompressed key would make Address() panic
+		if len(pk) != secp256k1.PubKeySize || (pk[0] != 0x02 && pk[0] != 0x03) {
+			return sdk.ErrInvalidPubKey(fmt.Sprintf(
+				"secp256k1 public key must be in the compressed form of %d bytes, got %d bytes", secp256k1.PubKeySize, len(pk)))
+		}
+		return nil
+	case multisig.PubKeyMultisigThreshold:
+		for _, subKey := range pk.PubKeys {
+			if err := checkPubKeyType(subKey); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return sdk.ErrInvalidPubKey(fmt.Sprintf("unsupported public key type %T", pubKey))
+	}
+}
+
+// CompressSecp256k1PubKey returns the compressed form of a secp256k1 pubkey given in either the compressed or
+// the uncompressed form, e.g. by a hardware wallet. Only the compressed form is accepted in the signatures.
+func CompressSecp256k1PubKey(bz []byte) (secp256k1.PubKeySecp256k1, error) {
+	pk, err := btcec.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.PubKeySecp256k1(pk.SerializeCompressed()), nil
+}
+
+// checkDuplicateSigners makes sure no two signatures are made by the same pubkey. The pubkeys are compared
+// rather than their addresses, as the pubkey of a migrated account is not of its address.
+func checkDuplicateSigners(sigs []auth.StdSignature) sdk.Error {
+	signers := make(map[string]bool, len(sigs))
+	for _, sig := range sigs {
+		key := string(sig.PubKey.Bytes())
+		if signers[key] {
+			return sdk.ErrUnauthorized(fmt.Sprintf("duplicate signer %s", sdk.AccAddress(sig.PubKey.Address())))
+		}
+		signers[key] = true
+	}
+	return nil
+}
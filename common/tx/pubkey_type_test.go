@@ -0,0 +1,92 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/wire"
+)
+
+// dummyPubKey behaves like the wrapped secp256k1 key but is not a supported type
+type dummyPubKey struct {
+	secp256k1.PubKeySecp256k1
+}
+
+func TestAnteHandlerUnsupportedPubKey(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	txn.Signatures[0].PubKey = dummyPubKey{priv1.PubKey().(secp256k1.PubKeySecp256k1)}
+
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidPubKey), res.Code)
+	require.Contains(t, res.Log, "tx_test.dummyPubKey")
+	checkBalance(t, am, ctx, acc1.GetAddress(), acc1.GetCoins())
+}
+
+func TestAnteHandlerDuplicateSigners(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+
+	// both signatures are made by acc1, with conflicting sequences
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv1}, []int64{0, 0}, []int64{0, 1})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "duplicate signer")
+}
+
+func TestAnteHandlerCompressedSecp256k1PubKey(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	compressed := priv1.PubKey().(secp256k1.PubKeySecp256k1)
+	require.Len(t, compressed, secp256k1.PubKeySize)
+
+	// the uncompressed form of a hardware wallet maps to the same key and address
+	pk, err := btcec.ParsePubKey(compressed)
+	require.NoError(t, err)
+	uncompressed := pk.SerializeUncompressed()
+	pubKey, err := tx.CompressSecp256k1PubKey(uncompressed)
+	require.NoError(t, err)
+	require.Equal(t, compressed, pubKey)
+	require.Equal(t, acc1.GetAddress(), sdk.AccAddress(pubKey.Address()))
+
+	// the signature keeps the compressed form through amino
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	cdc := wire.NewCodec()
+	wire.RegisterCrypto(cdc)
+	bz, err := cdc.MarshalBinaryBare(txn.Signatures[0])
+	require.NoError(t, err)
+	var sig auth.StdSignature
+	require.NoError(t, cdc.UnmarshalBinaryBare(bz, &sig))
+	require.Equal(t, compressed, sig.PubKey)
+
+	// the uncompressed form is rejected rather than panicking when the address is derived
+	uncompressedTx := newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	uncompressedTx.Signatures[0].PubKey = secp256k1.PubKeySecp256k1(uncompressed)
+	_, res, abort := anteHandler(ctx, uncompressedTx, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidPubKey), res.Code)
+	require.Contains(t, res.Log, "compressed form")
+
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	stored := am.GetAccount(ctx, acc1.GetAddress()).GetPubKey()
+	require.Equal(t, compressed, stored)
+	require.Equal(t, acc1.GetAddress(), sdk.AccAddress(stored.Address()))
+}
@@ -0,0 +1,43 @@
+package tx
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bnb-chain/node/wire"
+)
+
+const (
+	QueryRoute = "fee"
+
+	QueryFeePool = "pool"
+)
+
+func NewQuerier(cdc *wire.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryFeePool:
+			return queryFeePool(cdc)
+		default:
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("unknown fee query endpoint %s", path[0]))
+		}
+	}
+}
+
+// query 'custom/fee/pool', returns the fees collected in the current block
+func queryFeePool(cdc *wire.Codec) ([]byte, sdk.Error) {
+	coins := sdkfees.Pool.BlockFees().Tokens
+	if coins == nil {
+		coins = sdk.Coins{}
+	}
+	bz, err := codec.MarshalJSONIndent(cdc, coins)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
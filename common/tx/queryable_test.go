@@ -0,0 +1,46 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func queryFeePool(t *testing.T, ctx sdk.Context, querier sdk.Querier) sdk.Coins {
+	bz, err := querier(ctx, []string{tx.QueryFeePool}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var coins sdk.Coins
+	require.NoError(t, wire.NewCodec().UnmarshalJSON(bz, &coins))
+	return coins
+}
+
+func TestQueryFeePool(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	querier := tx.NewQuerier(wire.NewCodec())
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+
+	sdkfees.Pool.Clear()
+	require.Empty(t, queryFeePool(t, ctx, querier))
+
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, priv1, acc1.GetAddress(),
+		sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer),
+		sdkfees.FreeFeeCalculator(),
+		sdkfees.FixedFeeCalculator(20, sdk.FeeForAll))
+	require.Equal(t, sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 30)}, queryFeePool(t, ctx, querier))
+
+	sdkfees.Pool.Clear()
+	require.Empty(t, queryFeePool(t, ctx, querier))
+
+	_, err := querier(ctx, []string{"unknown"}, abci.RequestQuery{})
+	require.NotNil(t, err)
+}
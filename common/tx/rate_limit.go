@@ -0,0 +1,80 @@
+package tx
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// txRateLimiter counts the txs each account has landed in the current block, separately for
+// CheckTx and DeliverTx. The counts are reset when the block height changes.
+// Every deliver mode is counted the same way, as the limit affects the consensus, and the limiter
+// is shared by the CheckTx and DeliverTx goroutines, so it's guarded by a lock.
+type txRateLimiter struct {
+	maxTxs int
+
+	mtx           sync.Mutex
+	checkCounts   blockTxCounts
+	deliverCounts blockTxCounts
+}
+
+type blockTxCounts struct {
+	height int64
+	counts map[string]int
+}
+
+func newTxRateLimiter(maxTxs int) *txRateLimiter {
+	return &txRateLimiter{maxTxs: maxTxs}
+}
+
+// countsOf returns the counts of the block for the mode, nil if the mode is not limited.
+// The lock must be held.
+func (l *txRateLimiter) countsOf(ctx sdk.Context, mode sdk.RunTxMode) map[string]int {
+	if l.maxTxs <= 0 {
+		return nil
+	}
+	var block *blockTxCounts
+	switch mode {
+	case sdk.RunTxModeCheck, sdk.RunTxModeCheckAfterPre:
+		block = &l.checkCounts
+	case sdk.RunTxModeDeliver, sdk.RunTxModeDeliverAfterPre:
+		block = &l.deliverCounts
+	default:
+		return nil
+	}
+	if block.counts == nil || block.height != ctx.BlockHeight() {
+		block.height = ctx.BlockHeight()
+		block.counts = make(map[string]int)
+	}
+	return block.counts
+}
+
+// check makes sure none of the signers has landed the max number of txs in the block
+func (l *txRateLimiter) check(ctx sdk.Context, mode sdk.RunTxMode, signers []sdk.AccAddress) sdk.Error {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	counts := l.countsOf(ctx, mode)
+	if counts == nil {
+		return nil
+	}
+	for _, signer := range signers {
+		if counts[string(signer.Bytes())] >= l.maxTxs {
+			return sdk.ErrUnauthorized(fmt.Sprintf("rate limited: %s has sent %d txs in this block", signer, l.maxTxs))
+		}
+	}
+	return nil
+}
+
+// add counts a tx of the signers which passed the ante handler
+func (l *txRateLimiter) add(ctx sdk.Context, mode sdk.RunTxMode, signers []sdk.AccAddress) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	counts := l.countsOf(ctx, mode)
+	if counts == nil {
+		return
+	}
+	for _, signer := range signers {
+		counts[string(signer.Bytes())]++
+	}
+}
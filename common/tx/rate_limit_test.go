@@ -0,0 +1,67 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerMaxTxsPerAccountPerBlock(t *testing.T) {
+	am, ctx, _ := setup()
+	opts := tx.DefaultAnteOptions()
+	opts.MaxTxsPerAccountPerBlock = 3
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	newTx := func(priv crypto.PrivKey, acc sdk.Account, seq int64) sdk.Tx {
+		msgs := []sdk.Msg{newTestMsg(acc.GetAddress())}
+		return newTestTx(ctx, msgs, []crypto.PrivKey{priv}, []int64{acc.GetAccountNumber()}, []int64{seq})
+	}
+
+	for seq := int64(0); seq < 3; seq++ {
+		checkValidTx(t, anteHandler, ctx, newTx(priv1, acc1, seq), sdk.RunTxModeDeliver)
+	}
+	_, res, abort := anteHandler(ctx, newTx(priv1, acc1, 3), sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Contains(t, res.Log, "rate limited")
+
+	// other accounts are not affected
+	checkValidTx(t, anteHandler, ctx, newTx(priv2, acc2, 0), sdk.RunTxModeDeliver)
+
+	// the counter resets in the next block
+	checkValidTx(t, anteHandler, ctx.WithBlockHeight(2), newTx(priv1, acc1, 3), sdk.RunTxModeDeliver)
+}
+
+func TestAnteHandlerMaxTxsPerAccountPerBlockAfterPre(t *testing.T) {
+	am, ctx, _ := setup()
+	opts := tx.DefaultAnteOptions()
+	opts.MaxTxsPerAccountPerBlock = 3
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	newTx := func(seq int64) sdk.Tx {
+		msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+		return newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{acc1.GetAccountNumber()}, []int64{seq})
+	}
+
+	// the txs of the block verified in advance count the same as the others
+	checkValidTx(t, anteHandler, ctx, newTx(0), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, newTx(1), sdk.RunTxModeDeliverAfterPre)
+	checkValidTx(t, anteHandler, ctx, newTx(2), sdk.RunTxModeDeliverAfterPre)
+	_, res, abort := anteHandler(ctx, newTx(3), sdk.RunTxModeDeliverAfterPre)
+	require.True(t, abort)
+	require.Contains(t, res.Log, "rate limited")
+	_, res, abort = anteHandler(ctx, newTx(3), sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Contains(t, res.Log, "rate limited")
+
+	// CheckTx is counted on its own
+	checkValidTx(t, anteHandler, ctx, newTx(3), sdk.RunTxModeCheckAfterPre)
+}
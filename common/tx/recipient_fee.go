@@ -0,0 +1,82 @@
+package tx
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var feeConsentKeyPrefix = []byte("feeConsent:")
+
+// RecipientFeeMsg is implemented by the transfer msgs whose fee can be paid by the recipient.
+type RecipientFeeMsg interface {
+	sdk.Msg
+	FeePaidByRecipient() bool
+	GetRecipient() sdk.AccAddress
+}
+
+// FeeConsentKeeper stores the pre-authorizations of recipients to pay the fees of the transfers from given senders.
+type FeeConsentKeeper struct {
+	key sdk.StoreKey
+}
+
+func NewFeeConsentKeeper(key sdk.StoreKey) FeeConsentKeeper {
+	return FeeConsentKeeper{key: key}
+}
+
+func feeConsentKey(recipient, sender sdk.AccAddress) []byte {
+	key := append(append([]byte{}, feeConsentKeyPrefix...), recipient.Bytes()...)
+	return append(key, sender.Bytes()...)
+}
+
+// AuthorizeFeePayment lets the recipient pay the fees of the transfers from the sender
+func (k FeeConsentKeeper) AuthorizeFeePayment(ctx sdk.Context, recipient, sender sdk.AccAddress) {
+	ctx.KVStore(k.key).Set(feeConsentKey(recipient, sender), []byte{1})
+}
+
+func (k FeeConsentKeeper) RevokeFeePayment(ctx sdk.Context, recipient, sender sdk.AccAddress) {
+	ctx.KVStore(k.key).Delete(feeConsentKey(recipient, sender))
+}
+
+func (k FeeConsentKeeper) IsFeePaymentAuthorized(ctx sdk.Context, recipient, sender sdk.AccAddress) bool {
+	return ctx.KVStore(k.key).Has(feeConsentKey(recipient, sender))
+}
+
+// the keeper used by the ante handler to check the consents, nil means no recipient has consented
+var feeConsentKeeper *FeeConsentKeeper
+
+func SetFeeConsentKeeper(k *FeeConsentKeeper) {
+	feeConsentKeeper = k
+}
+
+// getFeePayer returns the first signer, or the signer designated by the msg, or the recipient if the msg asks
+// for the fee to be paid by the recipient
+func getFeePayer(ctx sdk.Context, am auth.AccountKeeper, signerAccs []sdk.Account, msg sdk.Msg) (sdk.Account, sdk.Error) {
+	if payer, err := getDesignatedFeePayer(signerAccs, msg); err != nil || payer != nil {
+		return payer, err
+	}
+
+	sender := signerAccs[0]
+	recipientFeeMsg, ok := msg.(RecipientFeeMsg)
+	if !ok || !recipientFeeMsg.FeePaidByRecipient() {
+		return sender, nil
+	}
+
+	recipient := recipientFeeMsg.GetRecipient()
+	if feeConsentKeeper == nil || !feeConsentKeeper.IsFeePaymentAuthorized(ctx, recipient, sender.GetAddress()) {
+		return nil, sdk.ErrUnauthorized("recipient has not authorized paying the fee")
+	}
+
+	// the recipient may also be a signer, use the same account object so the changes are not overwritten
+	for _, acc := range signerAccs {
+		if bytes.Equal(acc.GetAddress(), recipient) {
+			return acc, nil
+		}
+	}
+	acc := am.GetAccount(ctx, recipient)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(recipient.String())
+	}
+	return acc, nil
+}
@@ -0,0 +1,110 @@
+package tx_test
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/common/upgrade"
+	"github.com/bnb-chain/node/plugins/tokens/swap"
+	"github.com/bnb-chain/node/wire"
+)
+
+type recipientFeeMsg struct {
+	*sdk.TestMsg
+	recipient       sdk.AccAddress
+	paidByRecipient bool
+}
+
+func (msg recipientFeeMsg) FeePaidByRecipient() bool     { return msg.paidByRecipient }
+func (msg recipientFeeMsg) GetRecipient() sdk.AccAddress { return msg.recipient }
+
+func TestAnteHandlerFeePaidByRecipient(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	consentKeeper := tx.NewFeeConsentKeeper(capKey2)
+	tx.SetFeeConsentKeeper(&consentKeeper)
+	defer tx.SetFeeConsentKeeper(nil)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 100)
+	msg := recipientFeeMsg{
+		TestMsg:         newTestMsgWithFeeCalculator(sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer), acc1.GetAddress()),
+		recipient:       acc2.GetAddress(),
+		paidByRecipient: true,
+	}
+
+	// the recipient hasn't consented, the state changes are discarded like BaseApp does for failed txs
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+
+	// the recipient has consented
+	consentKeeper.AuthorizeFeePayment(ctx, acc2.GetAddress(), acc1.GetAddress())
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	sdkfees.Pool.Clear()
+	tx.FeePayers.Clear()
+
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+	checkBalance(t, am, ctx, acc2.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+}
+
+func TestAnteHandlerHTLTFeePaidByRecipient(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	consentKeeper := tx.NewFeeConsentKeeper(capKey2)
+	tx.SetFeeConsentKeeper(&consentKeeper)
+	defer tx.SetFeeConsentKeeper(nil)
+	anteHandler := tx.NewAnteHandler(am)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	_, acc2 := testutils.NewAccount(ctx, am, 100)
+	randomNumberHash, _ := hex.DecodeString("be543130668282f267580badb1c956dacd4502be3b57846443c9921118ffa167")
+	msg := swap.NewHTLTMsg(acc1.GetAddress(), acc2.GetAddress(), "", "", randomNumberHash, 1564471835,
+		sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, "10:BNB", 1000, false)
+	sdkfees.UnsetAllCalculators()
+	sdkfees.RegisterCalculator(msg.Type(), sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+
+	// the sign bytes of the HTLTs not paid by the recipient are unchanged
+	require.NotContains(t, string(msg.GetSignBytes()), "recipient_pays_fee")
+	msg.RecipientPaysFee = true
+	require.Error(t, msg.ValidateBasic())
+	upgrade.Mgr.AddUpgradeHeight(upgrade.RecipientPaysFee, -1)
+	defer upgrade.Mgr.AddUpgradeHeight(upgrade.RecipientPaysFee, math.MaxInt64)
+	require.NoError(t, msg.ValidateBasic())
+
+	// the recipient hasn't consented
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+
+	consentKeeper.AuthorizeFeePayment(ctx, acc2.GetAddress(), acc1.GetAddress())
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	sdkfees.Pool.Clear()
+	tx.FeePayers.Clear()
+
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+	checkBalance(t, am, ctx, acc2.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+}
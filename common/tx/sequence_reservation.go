@@ -0,0 +1,68 @@
+package tx
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// the next sequence not reserved yet, of the accounts which have reserved sequences
+var sequenceReservations = struct {
+	sync.Mutex
+	next map[string]int64
+}{next: make(map[string]int64)}
+
+// ReserveSequences reserves count consecutive sequences of the account for the txs a client is going to submit,
+// and returns the first one. The reservations start from the sequence of the account in the CheckTx state and
+// follow each other, so clients pipelining txs don't get the same sequences.
+// The account is not changed, so it has no effect on consensus.
+func ReserveSequences(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, count int) (int64, sdk.Error) {
+	if count <= 0 {
+		return 0, sdk.ErrUnknownRequest(fmt.Sprintf("invalid number of sequences to reserve %d", count))
+	}
+	start, err := NextReservableSequence(ctx, am, addr)
+	if err != nil {
+		return 0, err
+	}
+
+	sequenceReservations.Lock()
+	defer sequenceReservations.Unlock()
+	// another reservation may have been made in the meantime
+	if next, ok := sequenceReservations.next[string(addr)]; ok && next > start {
+		start = next
+	}
+	sequenceReservations.next[string(addr)] = start + int64(count)
+	return start, nil
+}
+
+// NextReservableSequence returns the sequence the next reservation of the account starts from, which is the
+// sequence of the account if it has caught up with the reservations.
+func NextReservableSequence(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress) (int64, sdk.Error) {
+	if !ctx.IsCheckTx() {
+		return 0, sdk.ErrInternal("sequences can only be reserved in the CheckTx state")
+	}
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return 0, sdk.ErrUnknownAddress(addr.String())
+	}
+
+	sequenceReservations.Lock()
+	defer sequenceReservations.Unlock()
+	seq := acc.GetSequence()
+	if next, ok := sequenceReservations.next[string(addr)]; ok {
+		if next > seq {
+			return next, nil
+		}
+		delete(sequenceReservations.next, string(addr))
+	}
+	return seq, nil
+}
+
+// ResetSequenceReservations drops all the reservations, e.g. when the mempool is flushed
+func ResetSequenceReservations() {
+	sequenceReservations.Lock()
+	defer sequenceReservations.Unlock()
+	sequenceReservations.next = make(map[string]int64)
+}
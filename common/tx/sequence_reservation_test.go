@@ -0,0 +1,55 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestReserveSequences(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	defer tx.ResetSequenceReservations()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	addr1 := acc1.GetAddress()
+
+	// only against the CheckTx state
+	_, err := tx.ReserveSequences(ctx, am, addr1, 5)
+	require.Error(t, err)
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	_, err = tx.ReserveSequences(ctx, am, addr1, 0)
+	require.Error(t, err)
+
+	start, err := tx.ReserveSequences(ctx, am, addr1, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), start)
+	next, err := tx.NextReservableSequence(ctx, am, addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), next)
+	start, err = tx.ReserveSequences(ctx, am, addr1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), start)
+
+	// the account is not changed, so the reserved sequences can be used
+	require.Equal(t, int64(0), am.GetAccount(ctx, addr1).GetSequence())
+	for seq := int64(0); seq < 7; seq++ {
+		txn := newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, []int64{0}, []int64{seq})
+		checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	}
+	next, err = tx.NextReservableSequence(ctx, am, addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), next)
+
+	// the account has caught up, and goes on from its own sequence
+	txn := newTestTx(ctx, []sdk.Msg{newTestMsg(addr1)}, []crypto.PrivKey{priv1}, []int64{0}, []int64{7})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeCheck)
+	start, err = tx.ReserveSequences(ctx, am, addr1, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), start)
+}
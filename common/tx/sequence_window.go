@@ -0,0 +1,94 @@
+package tx
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const maxSequenceWindow = 64
+
+var usedSequencesKeyPrefix = []byte("usedSeqs:")
+
+// SequenceWindowKeeper stores, per account, a bitmap of the sequences that have been used
+// above the account's current sequence. Bit i stands for sequence `acc.GetSequence() + i`.
+type SequenceWindowKeeper struct {
+	key sdk.StoreKey
+}
+
+func NewSequenceWindowKeeper(key sdk.StoreKey) SequenceWindowKeeper {
+	return SequenceWindowKeeper{key: key}
+}
+
+func usedSequencesKey(addr sdk.AccAddress) []byte {
+	return append(usedSequencesKeyPrefix, addr.Bytes()...)
+}
+
+func (k SequenceWindowKeeper) getUsedSequences(ctx sdk.Context, addr sdk.AccAddress) uint64 {
+	bz := ctx.KVStore(k.key).Get(usedSequencesKey(addr))
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+func (k SequenceWindowKeeper) setUsedSequences(ctx sdk.Context, addr sdk.AccAddress, used uint64) {
+	store := ctx.KVStore(k.key)
+	if used == 0 {
+		store.Delete(usedSequencesKey(addr))
+		return
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, used)
+	store.Set(usedSequencesKey(addr), bz)
+}
+
+// acceptSequence marks seq as used if it's inside the window [acc.GetSequence(), acc.GetSequence()+size)
+// and hasn't been used yet. The account's sequence slides forward over all the consecutive used sequences.
+func (k SequenceWindowKeeper) acceptSequence(ctx sdk.Context, acc sdk.Account, seq int64, size int64) sdk.Error {
+	base := acc.GetSequence()
+	if seq < base || seq >= base+size {
+		return sdk.ErrInvalidSequence(
+			fmt.Sprintf("Invalid sequence. Got %d, expected in [%d, %d)", seq, base, base+size))
+	}
+
+	used := k.getUsedSequences(ctx, acc.GetAddress())
+	offset := uint64(seq - base)
+	if used&(1<<offset) != 0 {
+		return sdk.ErrInvalidSequence(fmt.Sprintf("Invalid sequence. Sequence %d has been used", seq))
+	}
+
+	used |= 1 << offset
+	for used&1 != 0 {
+		used >>= 1
+		base++
+	}
+	if err := acc.SetSequence(base); err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	k.setUsedSequences(ctx, acc.GetAddress(), used)
+	return nil
+}
+
+// checkSequenceOptions panics if the sequence window or increment of the options is invalid
+func checkSequenceOptions(opts AnteOptions) {
+	if opts.SequenceWindow < 0 || opts.SequenceWindow > maxSequenceWindow {
+		panic(fmt.Errorf("sequence window size should be in [0, %d]", maxSequenceWindow))
+	}
+	if opts.SequenceWindow > 1 && opts.SequenceWindowKeeper == nil {
+		panic(fmt.Errorf("sequence window keeper is required for window size %d", opts.SequenceWindow))
+	}
+	if opts.SequenceIncrement < 0 {
+		panic(fmt.Errorf("sequence increment should not be negative, got %d", opts.SequenceIncrement))
+	}
+}
+
+// sequenceIncrement returns the step the sequence of the options advances by, which is 1 if none is set
+func (opts AnteOptions) sequenceIncrement() int64 {
+	if opts.SequenceIncrement == 0 {
+		return 1
+	}
+	return opts.SequenceIncrement
+}
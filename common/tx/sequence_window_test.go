@@ -0,0 +1,88 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/wire"
+)
+
+func TestAnteHandlerSequenceWindow(t *testing.T) {
+	ms, capKey, capKey2 := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	auth.RegisterBaseAccount(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, auth.ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	seqWindowKeeper := tx.NewSequenceWindowKeeper(capKey2)
+	opts := tx.DefaultAnteOptions()
+	opts.SequenceWindow = 4
+	opts.SequenceWindowKeeper = &seqWindowKeeper
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	addr1 := acc1.GetAddress()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	runTx := func(seq int64) sdk.Tx {
+		return newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{seq})
+	}
+
+	// accepted out of order within the window
+	checkValidTx(t, anteHandler, ctx, runTx(2), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, runTx(1), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(0), am.GetAccount(ctx, addr1).GetSequence())
+
+	// duplicate and out of window sequences are rejected
+	checkInvalidTx(t, anteHandler, ctx, runTx(2), sdk.CodeInvalidSequence, sdk.RunTxModeDeliver)
+	checkInvalidTx(t, anteHandler, ctx, runTx(4), sdk.CodeInvalidSequence, sdk.RunTxModeDeliver)
+
+	// filling the base slides the window over the used sequences
+	checkValidTx(t, anteHandler, ctx, runTx(0), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(3), am.GetAccount(ctx, addr1).GetSequence())
+	checkInvalidTx(t, anteHandler, ctx, runTx(1), sdk.CodeInvalidSequence, sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, runTx(6), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, runTx(3), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(4), am.GetAccount(ctx, addr1).GetSequence())
+
+	// a window needs a keeper
+	opts.SequenceWindowKeeper = nil
+	require.Panics(t, func() { tx.NewAnteHandlerWithOptions(am, opts) })
+}
+
+func TestAnteHandlerSequenceIncrement(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	addr1 := acc1.GetAddress()
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	runTx := func(seq int64) sdk.Tx {
+		return newTestTx(ctx, msgs, []crypto.PrivKey{priv1}, []int64{0}, []int64{seq})
+	}
+
+	// increment 1 by default
+	checkValidTx(t, anteHandler, ctx, runTx(0), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(1), am.GetAccount(ctx, addr1).GetSequence())
+
+	opts := tx.DefaultAnteOptions()
+	opts.SequenceIncrement = 10
+	anteHandler = tx.NewAnteHandlerWithOptions(am, opts)
+
+	checkValidTx(t, anteHandler, ctx, runTx(1), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(11), am.GetAccount(ctx, addr1).GetSequence())
+	checkInvalidTx(t, anteHandler, ctx, runTx(2), sdk.CodeInvalidSequence, sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, runTx(11), sdk.RunTxModeDeliver)
+	require.Equal(t, int64(21), am.GetAccount(ctx, addr1).GetSequence())
+
+	opts.SequenceIncrement = -1
+	require.Panics(t, func() { tx.NewAnteHandlerWithOptions(am, opts) })
+}
@@ -0,0 +1,57 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerSigCacheMultiSigners(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithValue(baseapp.TxHashKey, "txhash")
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+
+	// a valid first signature doesn't let an invalid second one of the same tx through
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0})
+	txn.Signatures[1].Signature = txn.Signatures[0].Signature
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+}
+
+func benchmarkAnteHandlerSigCache(b *testing.B, cached bool) {
+	am, ctx, anteHandler := setup()
+	ctx = ctx.WithRunTxMode(sdk.RunTxModeCheck)
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	txn := newTestTx(ctx, []sdk.Msg{newTestMsg(acc1.GetAddress())}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	defer tx.InitSigCache(30000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !cached {
+			b.StopTimer()
+			tx.InitSigCache(1)
+			b.StartTimer()
+		}
+		// the sequence is checked every time, so run on a fresh cache of the state
+		cacheCtx, _ := ctx.CacheContext()
+		if _, res, abort := anteHandler(cacheCtx, txn, sdk.RunTxModeCheck); abort {
+			b.Fatal(res.Log)
+		}
+	}
+}
+
+func BenchmarkAnteHandlerUncachedSig(b *testing.B) {
+	benchmarkAnteHandlerSigCache(b, false)
+}
+
+func BenchmarkAnteHandlerCachedSig(b *testing.B) {
+	benchmarkAnteHandlerSigCache(b, true)
+}
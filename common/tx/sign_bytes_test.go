@@ -0,0 +1,33 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// goldenTestMsg has fixed sign bytes, with unsorted keys and whitespace
+type goldenTestMsg struct {
+	*sdk.TestMsg
+}
+
+func (msg goldenTestMsg) GetSignBytes() []byte {
+	return []byte(`{"to": "bnb1recipient", "denom": "BNB", "amount": 100}`)
+}
+
+// The sign bytes are the canonical JSON of the sign doc: keys sorted, no insignificant whitespace,
+// and the int64 fields quoted as strings. No field is omitted when it's empty, an empty memo is ""
+// and nil data is null. Clients signing on other platforms, e.g. hardware wallets, must produce these bytes.
+func TestStdSignBytesGolden(t *testing.T) {
+	msg := goldenTestMsg{sdk.NewTestMsg()}
+	expected := `{"account_number":"12","chain_id":"Binance-Chain-Tigris","data":null,"memo":"golden memo",` +
+		`"msgs":[{"amount":100,"denom":"BNB","to":"bnb1recipient"}],"sequence":"3","source":"1"}`
+	require.Equal(t, expected, string(auth.StdSignBytes("Binance-Chain-Tigris", 12, 3, []sdk.Msg{msg}, "golden memo", 1, nil)))
+
+	expected = `{"account_number":"0","chain_id":"","data":null,"memo":"",` +
+		`"msgs":[{"amount":100,"denom":"BNB","to":"bnb1recipient"}],"sequence":"0","source":"0"}`
+	require.Equal(t, expected, string(auth.StdSignBytes("", 0, 0, []sdk.Msg{msg}, "", 0, nil)))
+}
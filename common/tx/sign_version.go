@@ -0,0 +1,45 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SignBytesFunc builds the bytes a signer signs for a tx
+type SignBytesFunc func(chainID string, accnum int64, sequence int64, msgs []sdk.Msg, memo string, source int64, data []byte) []byte
+
+const SignVersion1 = 1
+
+var (
+	signVersions         = map[int]SignBytesFunc{SignVersion1: auth.StdSignBytes}
+	acceptedSignVersions = []int{SignVersion1}
+)
+
+// RegisterSignVersion registers the sign bytes format of the version
+func RegisterSignVersion(version int, signBytes SignBytesFunc) {
+	signVersions[version] = signBytes
+}
+
+// SetAcceptedSignVersions sets the versions of the sign bytes the ante handler accepts, so clients can
+// migrate to a new format while the old one is still accepted. A signature is valid if it's valid for any of them.
+func SetAcceptedSignVersions(versions ...int) {
+	if len(versions) == 0 {
+		panic(fmt.Errorf("at least one sign version should be accepted"))
+	}
+	for _, version := range versions {
+		if _, ok := signVersions[version]; !ok {
+			panic(fmt.Errorf("sign version %d is not registered", version))
+		}
+	}
+	acceptedSignVersions = versions
+}
+
+func acceptedSignBytes(chainID string, accnum int64, sequence int64, msgs []sdk.Msg, memo string, source int64, data []byte) [][]byte {
+	signBytes := make([][]byte, 0, len(acceptedSignVersions))
+	for _, version := range acceptedSignVersions {
+		signBytes = append(signBytes, signVersions[version](chainID, accnum, sequence, msgs, memo, source, data))
+	}
+	return signBytes
+}
@@ -0,0 +1,45 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func signBytesV2(chainID string, accnum int64, sequence int64, msgs []sdk.Msg, memo string, source int64, data []byte) []byte {
+	return append([]byte("v2:"), auth.StdSignBytes(chainID, accnum, sequence, msgs, memo, source, data)...)
+}
+
+func TestAnteHandlerSignVersions(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress())}
+	privs, accNums := []crypto.PrivKey{priv1}, []int64{0}
+
+	tx.RegisterSignVersion(2, signBytesV2)
+	defer tx.SetAcceptedSignVersions(tx.SignVersion1)
+
+	// v2 signed txs are rejected until v2 is accepted
+	txV2 := newTestTxWithSignBytes(msgs, privs, accNums, []int64{0}, signBytesV2(ctx.ChainID(), 0, 0, msgs, "", 0, nil), "")
+	checkInvalidTx(t, anteHandler, ctx, txV2, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+
+	tx.SetAcceptedSignVersions(tx.SignVersion1, 2)
+
+	// v1 signed tx verifies under v1 reconstruction
+	txV1 := newTestTxWithSignBytes(msgs, privs, accNums, []int64{0}, auth.StdSignBytes(ctx.ChainID(), 0, 0, msgs, "", 0, nil), "")
+	checkValidTx(t, anteHandler, ctx, txV1, sdk.RunTxModeDeliver)
+
+	// v2 signed tx verifies under v2 reconstruction
+	txV2 = newTestTxWithSignBytes(msgs, privs, accNums, []int64{1}, signBytesV2(ctx.ChainID(), 0, 1, msgs, "", 0, nil), "")
+	checkValidTx(t, anteHandler, ctx, txV2, sdk.RunTxModeDeliver)
+
+	require.Panics(t, func() { tx.SetAcceptedSignVersions(3) })
+}
@@ -0,0 +1,102 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// SignatureStatus tells which of the required signers have signed the tx and which are still missing.
+// A signer has signed if the signature at its position in tx.GetSigners is not empty. The pubkey of the
+// signature is not matched with the signer's address, as a migrated account signs with a pubkey of another
+// address. The signatures are not verified here.
+func SignatureStatus(tx auth.StdTx, required []sdk.AccAddress) (signed, missing []sdk.AccAddress) {
+	for _, addr := range required {
+		if hasSignature(tx, addr) {
+			signed = append(signed, addr)
+		} else {
+			missing = append(missing, addr)
+		}
+	}
+	return signed, missing
+}
+
+func hasSignature(tx auth.StdTx, addr sdk.AccAddress) bool {
+	sig, ok := GetSignatureForSigner(tx, addr)
+	return ok && len(sig.Signature) > 0
+}
+
+// GetSignatureForSigner returns the signature at the position of the signer in tx.GetSigners,
+// which is the one the ante handler verifies for the signer
+func GetSignatureForSigner(tx auth.StdTx, addr sdk.AccAddress) (auth.StdSignature, bool) {
+	index := signerIndex(tx, addr)
+	if index < 0 || index >= len(tx.Signatures) || tx.Signatures[index].PubKey == nil {
+		return auth.StdSignature{}, false
+	}
+	return tx.Signatures[index], true
+}
+
+// AddSignature returns a copy of the tx with the signature of the signer added, e.g. to collect the signatures
+// of a multi-signer tx one by one offline. The signature is put at the position of the signer in tx.GetSigners,
+// which is the order the ante handler expects, so the tx is valid once all the signers have signed.
+// The signature is not verified here.
+func AddSignature(tx auth.StdTx, signer sdk.AccAddress, sig auth.StdSignature) (auth.StdTx, sdk.Error) {
+	if sig.PubKey == nil {
+		return tx, sdk.ErrInvalidPubKey("public key of signature should not be nil")
+	}
+	if sig.AccountNumber < 0 || sig.Sequence < 0 {
+		return tx, sdk.ErrInvalidSequence("account number and sequence of signature should not be negative")
+	}
+	index := signerIndex(tx, signer)
+	if index < 0 {
+		return tx, sdk.ErrUnauthorized(fmt.Sprintf("%s is not a signer of the tx", signer))
+	}
+	if _, ok := GetSignatureForSigner(tx, signer); ok {
+		return tx, sdk.ErrUnauthorized(fmt.Sprintf("duplicate signer %s", signer))
+	}
+
+	signers := len(tx.GetSigners())
+	if len(tx.Signatures) > signers {
+		return tx, sdk.ErrUnauthorized("wrong number of signers")
+	}
+	// the signers which haven't signed yet keep an empty signature, so the tx is invalid until they sign
+	sigs := make([]auth.StdSignature, signers)
+	copy(sigs, tx.Signatures)
+	sigs[index] = sig
+	tx.Signatures = sigs
+	return tx, nil
+}
+
+// signerIndex returns the position of the signer in tx.GetSigners, -1 if it's not a signer of the tx
+func signerIndex(tx auth.StdTx, addr sdk.AccAddress) int {
+	for i, signer := range tx.GetSigners() {
+		if bytes.Equal(signer, addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// RequiredSignatures returns the number of the distinct signers a tx over the msgs needs,
+// the same as the length of StdTx.GetSigners.
+func RequiredSignatures(msgs []sdk.Msg) int {
+	return len(OrderedSigners(msgs))
+}
+
+// OrderedSigners returns the signers of the msgs in the order their signatures are expected by the ante handler,
+// the same as StdTx.GetSigners: in the order they first appear in the msgs, without duplicates.
+func OrderedSigners(msgs []sdk.Msg) []sdk.AccAddress {
+	seen := make(map[string]bool)
+	var signers []sdk.AccAddress
+	for _, msg := range msgs {
+		for _, addr := range msg.GetSigners() {
+			if !seen[string(addr.Bytes())] {
+				signers = append(signers, addr)
+				seen[string(addr.Bytes())] = true
+			}
+		}
+	}
+	return signers
+}
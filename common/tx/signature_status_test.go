@@ -0,0 +1,101 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestSignatureStatus(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	_, acc3 := testutils.NewAccount(ctx, am, 100)
+	required := []sdk.AccAddress{acc1.GetAddress(), acc2.GetAddress(), acc3.GetAddress()}
+	msgs := []sdk.Msg{newTestMsg(required...)}
+
+	// 2 of 3 signed
+	txn := newTestTx(ctx, msgs, []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0})
+	signed, missing := tx.SignatureStatus(txn, required)
+	require.Equal(t, []sdk.AccAddress{acc1.GetAddress(), acc2.GetAddress()}, signed)
+	require.Equal(t, []sdk.AccAddress{acc3.GetAddress()}, missing)
+
+	// an empty signature doesn't count
+	txn.Signatures[1].Signature = nil
+	signed, missing = tx.SignatureStatus(txn, required)
+	require.Equal(t, []sdk.AccAddress{acc1.GetAddress()}, signed)
+	require.Equal(t, []sdk.AccAddress{acc2.GetAddress(), acc3.GetAddress()}, missing)
+}
+
+func TestRequiredSignatures(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+
+	for _, msgs := range [][]sdk.Msg{
+		// single signer
+		{sdk.NewTestMsg(addr1)},
+		// multiple signers
+		{sdk.NewTestMsg(addr1, addr2), sdk.NewTestMsg(addr3)},
+		// overlapping signers
+		{sdk.NewTestMsg(addr1, addr2), sdk.NewTestMsg(addr2, addr3), sdk.NewTestMsg(addr1)},
+	} {
+		stdTx := auth.NewStdTx(msgs, nil, "", 0, nil)
+		require.Equal(t, len(stdTx.GetSigners()), tx.RequiredSignatures(msgs))
+	}
+	require.Equal(t, 3, tx.RequiredSignatures([]sdk.Msg{sdk.NewTestMsg(addr1, addr2), sdk.NewTestMsg(addr2, addr3)}))
+}
+
+func TestAddSignature(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	priv3, acc3 := testutils.NewAccount(ctx, am, 100)
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+	signatureOf := func(priv crypto.PrivKey, accNum int64) auth.StdSignature {
+		return newTestTx(ctx, msgs, []crypto.PrivKey{priv}, []int64{accNum}, []int64{0}).Signatures[0]
+	}
+
+	// signed in the reverse order of the signers
+	txn := auth.NewStdTx(msgs, nil, "", 0, nil)
+	txn, err := tx.AddSignature(txn, acc2.GetAddress(), signatureOf(priv2, 1))
+	require.Nil(t, err)
+	_, ok := tx.GetSignatureForSigner(txn, acc1.GetAddress())
+	require.False(t, ok)
+	txn, err = tx.AddSignature(txn, acc1.GetAddress(), signatureOf(priv1, 0))
+	require.Nil(t, err)
+	sig, ok := tx.GetSignatureForSigner(txn, acc1.GetAddress())
+	require.True(t, ok)
+	require.Equal(t, signatureOf(priv1, 0), sig)
+
+	// duplicate and unknown signers
+	_, err = tx.AddSignature(txn, acc2.GetAddress(), signatureOf(priv2, 1))
+	require.NotNil(t, err)
+	_, err = tx.AddSignature(txn, acc3.GetAddress(), signatureOf(priv3, 2))
+	require.NotNil(t, err)
+
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
+
+func TestOrderedSigners(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	addr3 := sdk.AccAddress([]byte("addr3"))
+
+	// the same msgs as in TestAnteHandlerSigErrors
+	msgs := []sdk.Msg{sdk.NewTestMsg(addr1, addr2), sdk.NewTestMsg(addr3, addr1), sdk.NewTestMsg(addr2, addr3)}
+	require.Equal(t, []sdk.AccAddress{addr1, addr2, addr3}, tx.OrderedSigners(msgs))
+	require.Equal(t, auth.NewStdTx(msgs, nil, "", 0, nil).GetSigners(), tx.OrderedSigners(msgs))
+
+	msgs = []sdk.Msg{sdk.NewTestMsg(addr3, addr1), sdk.NewTestMsg(addr2, addr3), sdk.NewTestMsg(addr1, addr2)}
+	require.Equal(t, []sdk.AccAddress{addr3, addr1, addr2}, tx.OrderedSigners(msgs))
+	require.Equal(t, auth.NewStdTx(msgs, nil, "", 0, nil).GetSigners(), tx.OrderedSigners(msgs))
+}
@@ -0,0 +1,24 @@
+package tx
+
+import (
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// SignatureVerifier verifies the signatures of the txs in the ante handler
+type SignatureVerifier interface {
+	Verify(pubKey crypto.PubKey, msg, sig []byte) bool
+}
+
+type pubKeyVerifier struct{}
+
+func (pubKeyVerifier) Verify(pubKey crypto.PubKey, msg, sig []byte) bool {
+	return pubKey.VerifyBytes(msg, sig)
+}
+
+// sigVerifier returns the signature verifier of the options, which verifies with pubKey.VerifyBytes if none is set
+func (opts AnteOptions) sigVerifier() SignatureVerifier {
+	if opts.SignatureVerifier == nil {
+		return pubKeyVerifier{}
+	}
+	return opts.SignatureVerifier
+}
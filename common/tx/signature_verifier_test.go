@@ -0,0 +1,53 @@
+package tx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+type mockVerifier struct {
+	pass  bool
+	calls int
+}
+
+func (v *mockVerifier) Verify(pubKey crypto.PubKey, msg, sig []byte) bool {
+	v.calls++
+	return v.pass
+}
+
+func TestAnteHandlerSignatureVerifier(t *testing.T) {
+	am, ctx, defaultAnteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	verifier := &mockVerifier{pass: false}
+	opts := tx.DefaultAnteOptions()
+	opts.SignatureVerifier = verifier
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	// a valid signature is rejected by the verifier
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	cacheCtx, _ := ctx.CacheContext()
+	checkInvalidTx(t, anteHandler, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+	require.Equal(t, 1, verifier.calls)
+
+	// an invalid signature is accepted by the verifier
+	verifier.pass = true
+	otherChainTx := newTestTxWithSignBytes([]sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0},
+		auth.StdSignBytes("otherchainid", 0, 0, []sdk.Msg{msg}, "", 0, nil), "")
+	cacheCtx, _ = ctx.CacheContext()
+	checkValidTx(t, anteHandler, cacheCtx, otherChainTx, sdk.RunTxModeDeliver)
+	require.Equal(t, 2, verifier.calls)
+
+	// the signature accepted by the verifier is not cached for the other ante handlers
+	checkInvalidTx(t, defaultAnteHandler, ctx, otherChainTx, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+}
@@ -0,0 +1,41 @@
+package tx_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerStrictSignerOrder(t *testing.T) {
+	am, ctx, lenient := setup()
+	opts := tx.DefaultAnteOptions()
+	opts.StrictSignerOrder = true
+	strict := tx.NewAnteHandlerWithOptions(am, opts)
+
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	priv2, acc2 := testutils.NewAccount(ctx, am, 100)
+	// the pubkeys are known, so the signatures are verified with them
+	_ = acc1.SetPubKey(priv1.PubKey())
+	am.SetAccount(ctx, acc1)
+	_ = acc2.SetPubKey(priv2.PubKey())
+	am.SetAccount(ctx, acc2)
+
+	msgs := []sdk.Msg{newTestMsg(acc1.GetAddress(), acc2.GetAddress())}
+	privs, accNums, seqs := []crypto.PrivKey{priv1, priv2}, []int64{acc1.GetAccountNumber(), acc2.GetAccountNumber()}, []int64{0, 0}
+	txn := newTestTx(ctx, msgs, privs, accNums, seqs)
+	cacheCtx, _ := ctx.CacheContext()
+	checkValidTx(t, strict, cacheCtx, txn, sdk.RunTxModeDeliver)
+
+	// swap the pubkeys of the signatures, the signatures themselves are still in order
+	sigs := txn.Signatures
+	sigs[0].PubKey, sigs[1].PubKey = sigs[1].PubKey, sigs[0].PubKey
+	cacheCtx, _ = ctx.CacheContext()
+	checkInvalidTx(t, strict, cacheCtx, txn, sdk.CodeUnauthorized, sdk.RunTxModeDeliver)
+	cacheCtx, _ = ctx.CacheContext()
+	checkValidTx(t, lenient, cacheCtx, txn, sdk.RunTxModeDeliver)
+}
@@ -0,0 +1,24 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// the msg types that are free in simulation, keyed by msg type
+var simulationFreeMsgTypes = make(map[string]bool)
+
+// SetSimulationFreeMsgType makes the msgs of the type free when the tx is simulated,
+// they are still charged by their calculators when the tx is checked or delivered.
+func SetSimulationFreeMsgType(msgType string) {
+	simulationFreeMsgTypes[msgType] = true
+}
+
+func UnsetSimulationFreeMsgTypes() {
+	for msgType := range simulationFreeMsgTypes {
+		delete(simulationFreeMsgTypes, msgType)
+	}
+}
+
+func isSimulationFree(msg sdk.Msg, mode sdk.RunTxMode) bool {
+	return mode == sdk.RunTxModeSimulate && simulationFreeMsgTypes[msg.Type()]
+}
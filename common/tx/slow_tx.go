@@ -0,0 +1,37 @@
+package tx
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+var (
+	// txs spending longer than the threshold in the ante handler are logged, 0 disables the logging
+	slowTxThreshold time.Duration
+	wallClock       = time.Now
+)
+
+// SetSlowTxThreshold makes the ante handler log a warning for the txs whose ante processing exceeds d, 0 disables it.
+func SetSlowTxThreshold(d time.Duration) {
+	slowTxThreshold = d
+}
+
+// SetWallClock replaces the source of the wall time used to measure the ante processing, nil restores time.Now.
+func SetWallClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	wallClock = now
+}
+
+func logSlowTx(ctx sdk.Context, start time.Time, stdTx auth.StdTx) {
+	if slowTxThreshold <= 0 {
+		return
+	}
+	if elapsed := wallClock().Sub(start); elapsed > slowTxThreshold {
+		ctx.Logger().Info("slow tx in ante handler", "elapsed", elapsed,
+			"signers", len(stdTx.GetSigners()), "msgs", len(stdTx.GetMsgs()))
+	}
+}
@@ -0,0 +1,58 @@
+package tx_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+// steppingClock advances by step on every reading
+type steppingClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func TestAnteHandlerSlowTxLog(t *testing.T) {
+	am, ctx, anteHandler := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	var buf bytes.Buffer
+	ctx = ctx.WithLogger(log.NewTMLogger(log.NewSyncWriter(&buf)))
+
+	timer := &steppingClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tx.SetWallClock(timer.Now)
+	defer tx.SetWallClock(nil)
+	tx.SetSlowTxThreshold(100 * time.Millisecond)
+	defer tx.SetSlowTxThreshold(0)
+
+	// under the threshold
+	timer.step = 50 * time.Millisecond
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	require.NotContains(t, buf.String(), "slow tx")
+
+	// over the threshold
+	timer.step = 200 * time.Millisecond
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+	require.Contains(t, buf.String(), "slow tx")
+	require.Contains(t, buf.String(), "signers=1")
+	require.Contains(t, buf.String(), "msgs=1")
+}
@@ -0,0 +1,44 @@
+package tx
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TimeWindow is a block time range [Start, End) in which txs are rejected.
+// If MsgTypes is empty, all txs are rejected, otherwise only the txs carrying any of these msg types.
+type TimeWindow struct {
+	Start    time.Time
+	End      time.Time
+	MsgTypes []string
+}
+
+func (w TimeWindow) contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+func (w TimeWindow) blocks(msgs []sdk.Msg) bool {
+	if len(w.MsgTypes) == 0 {
+		return true
+	}
+	for _, msg := range msgs {
+		for _, msgType := range w.MsgTypes {
+			if msg.Type() == msgType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkBlockedTimeWindows(blockTime time.Time, msgs []sdk.Msg, windows []TimeWindow) sdk.Error {
+	for _, window := range windows {
+		if window.contains(blockTime) && window.blocks(msgs) {
+			return ErrTxInBlockedTimeWindow(fmt.Sprintf("tx is not allowed between %s and %s",
+				window.Start.UTC().Format(time.RFC3339), window.End.UTC().Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package tx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestAnteHandlerBlockedTimeWindows(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	opts := tx.DefaultAnteOptions()
+	opts.BlockedTimeWindows = []tx.TimeWindow{{Start: start, End: start.Add(time.Hour)}}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+
+	// inside the blocked window
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx.WithBlockTime(start.Add(time.Minute)), txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeTxInBlockedTimeWindow), res.Code)
+
+	// the end of the window is not blocked
+	checkValidTx(t, anteHandler, ctx.WithBlockTime(start.Add(time.Hour)), txn, sdk.RunTxModeDeliver)
+
+	// the window only blocks other msg types
+	opts.BlockedTimeWindows = []tx.TimeWindow{{Start: start, End: start.Add(time.Hour), MsgTypes: []string{"maintenance"}}}
+	anteHandler = tx.NewAnteHandlerWithOptions(am, opts)
+	txn = newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{1})
+	checkValidTx(t, anteHandler, ctx.WithBlockTime(start.Add(time.Minute)), txn, sdk.RunTxModeDeliver)
+}
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c mockClock) Now(ctx sdk.Context) time.Time {
+	return c.now
+}
+
+func TestAnteHandlerBlockedTimeWindowsWithClock(t *testing.T) {
+	am, ctx, _ := setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	msg := newTestMsg(acc1.GetAddress())
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the block time is not in the window, but the clock is
+	opts := tx.DefaultAnteOptions()
+	opts.BlockedTimeWindows = []tx.TimeWindow{{Start: start, End: start.Add(time.Hour)}}
+	opts.Clock = mockClock{now: start.Add(time.Minute)}
+	anteHandler := tx.NewAnteHandlerWithOptions(am, opts)
+	txn := newTestTx(ctx, []sdk.Msg{msg}, []crypto.PrivKey{priv1}, []int64{0}, []int64{0})
+	_, res, abort := anteHandler(ctx, txn, sdk.RunTxModeDeliver)
+	require.True(t, abort)
+	require.Equal(t, sdk.ToABCICode(tx.DefaultCodespace, tx.CodeTxInBlockedTimeWindow), res.Code)
+
+	opts.Clock = mockClock{now: start.Add(time.Hour)}
+	anteHandler = tx.NewAnteHandlerWithOptions(am, opts)
+	checkValidTx(t, anteHandler, ctx, txn, sdk.RunTxModeDeliver)
+}
@@ -0,0 +1,55 @@
+package tx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+)
+
+func TestValidateBasic(t *testing.T) {
+	ctx := sdk.NewContext(nil, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeCheck, log.NewNopLogger())
+	priv1, addr1 := testutils.PrivAndAddr()
+	priv2, addr2 := testutils.PrivAndAddr()
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs, accNums, seqs := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
+
+	validTx := func() auth.StdTx {
+		return newTestTx(ctx, msgs, privs, accNums, seqs)
+	}
+	require.Nil(t, tx.ValidateBasic(validTx()))
+
+	cases := []struct {
+		name   string
+		modify func(txn *auth.StdTx)
+		code   sdk.CodeType
+	}{
+		{"no msgs", func(txn *auth.StdTx) { txn.Msgs = nil }, sdk.CodeUnauthorized},
+		{"no signatures", func(txn *auth.StdTx) { txn.Signatures = nil }, sdk.CodeUnauthorized},
+		{"nil pubkey", func(txn *auth.StdTx) { txn.Signatures[1].PubKey = nil }, sdk.CodeInvalidPubKey},
+		{"nil msg", func(txn *auth.StdTx) { txn.Msgs = []sdk.Msg{nil} }, sdk.CodeUnknownRequest},
+		{"wrong signer count", func(txn *auth.StdTx) { txn.Signatures = txn.Signatures[:1] }, sdk.CodeUnauthorized},
+		{"invalid signer address", func(txn *auth.StdTx) {
+			txn.Msgs = []sdk.Msg{newTestMsg(addr1, addr2[:10])}
+		}, sdk.CodeInvalidAddress},
+		{"data not allowed", func(txn *auth.StdTx) { txn.Data = []byte("data") }, sdk.CodeUnauthorized},
+		{"memo too large", func(txn *auth.StdTx) { txn.Memo = strings.Repeat("m", 129) }, sdk.CodeMemoTooLarge},
+	}
+	for _, c := range cases {
+		txn := validTx()
+		c.modify(&txn)
+		err := tx.ValidateBasic(txn)
+		require.NotNil(t, err, c.name)
+		require.Equal(t, c.code, err.Code(), c.name)
+	}
+}
@@ -0,0 +1,27 @@
+package tx
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorChecker tells whether the account belongs to a current signing validator
+type ValidatorChecker func(ctx sdk.Context, addr sdk.AccAddress) bool
+
+var (
+	feeExemptValidators bool
+	validatorChecker    ValidatorChecker
+)
+
+// SetFeeExemptValidators makes the ante handler waive the fees paid by the current signing validators.
+// The validators are recognized by the checker set with SetValidatorChecker.
+func SetFeeExemptValidators(exempt bool) {
+	feeExemptValidators = exempt
+}
+
+func SetValidatorChecker(checker ValidatorChecker) {
+	validatorChecker = checker
+}
+
+func isFeeExempt(ctx sdk.Context, payer sdk.AccAddress) bool {
+	return feeExemptValidators && validatorChecker != nil && validatorChecker(ctx, payer)
+}
@@ -0,0 +1,37 @@
+package tx_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkfees "github.com/cosmos/cosmos-sdk/types/fees"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/tx"
+	"github.com/bnb-chain/node/common/types"
+)
+
+func TestAnteHandlerFeeExemptValidators(t *testing.T) {
+	var validator sdk.AccAddress
+	tx.SetValidatorChecker(func(ctx sdk.Context, addr sdk.AccAddress) bool {
+		return addr.Equals(validator)
+	})
+	tx.SetFeeExemptValidators(true)
+	defer tx.SetValidatorChecker(nil)
+	defer tx.SetFeeExemptValidators(false)
+
+	// the validator pays no fee
+	am, ctx, anteHandler := setup()
+	valPriv, valAcc := testutils.NewAccount(ctx, am, 100)
+	validator = valAcc.GetAddress()
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, valPriv, valAcc.GetAddress(), sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	checkBalance(t, am, ctx, valAcc.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 100)})
+	checkFee(t, sdk.Fee{})
+
+	// others are charged for the same msg type
+	am, ctx, anteHandler = setup()
+	priv1, acc1 := testutils.NewAccount(ctx, am, 100)
+	ctx = runAnteHandlerWithMultiTxFees(ctx, anteHandler, priv1, acc1.GetAddress(), sdkfees.FixedFeeCalculator(10, sdk.FeeForProposer))
+	checkBalance(t, am, ctx, acc1.GetAddress(), sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 90)})
+	checkFee(t, sdk.NewFee(sdk.Coins{sdk.NewCoin(types.NativeTokenSymbol, 10)}, sdk.FeeForProposer))
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"fmt"
+	"math"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
@@ -24,6 +28,34 @@ type NamedAccount interface {
 
 	GetFlags() uint64
 	SetFlags(uint64)
+
+	GetAvailableCoins() sdk.Coins
+
+	GetLockSchedule() []LockEntry
+	SetLockSchedule([]LockEntry)
+	GetVestingCoins() sdk.Coins
+	UnlockMaturedCoins(blockTime int64) sdk.Coins
+
+	SetFrozenUntil(denom string, height int64)
+	ThawExpiredCoins(height int64) sdk.Coins
+}
+
+const (
+	AccountNameMinLen = 1
+	AccountNameMaxLen = 32
+)
+
+// ValidateAccountName checks the name is 1-32 chars of letters, digits, '-', '_' and '.'
+func ValidateAccountName(name string) error {
+	if len(name) < AccountNameMinLen || len(name) > AccountNameMaxLen {
+		return fmt.Errorf("account name should be %d to %d chars, got %d chars", AccountNameMinLen, AccountNameMaxLen, len(name))
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return fmt.Errorf("account name contains invalid char %q", c)
+		}
+	}
+	return nil
 }
 
 // Custom extensions for this application.  This is just an example of
@@ -36,21 +68,197 @@ var _ NamedAccount = (*AppAccount)(nil)
 
 type AppAccount struct {
 	auth.BaseAccount `json:"base"`
-	Name             string    `json:"name"`
-	FrozenCoins      sdk.Coins `json:"frozen"`
-	LockedCoins      sdk.Coins `json:"locked"`
-	Flags            uint64    `json:"flags"`
+	Name             string         `json:"name"`
+	FrozenCoins      sdk.Coins      `json:"frozen"`
+	LockedCoins      sdk.Coins      `json:"locked"`
+	Flags            uint64         `json:"flags"`
+	LockSchedule     []LockEntry    `json:"lock_schedule"`
+	FrozenExpiries   []FrozenExpiry `json:"frozen_expiries"`
+}
+
+// FrozenExpiry is the height from which the frozen coins of the denom are thawed
+type FrozenExpiry struct {
+	Denom  string `json:"denom"`
+	Height int64  `json:"height"`
+}
+
+// LockEntry is a part of the vesting coins of an account which is unlocked at UnlockTime, in unix seconds.
+// The vesting coins are kept apart from the locked coins, which back the open orders on the DEX.
+type LockEntry struct {
+	UnlockTime int64     `json:"unlock_time"`
+	Coins      sdk.Coins `json:"coins"`
 }
 
 // nolint
-func (acc AppAccount) GetName() string                  { return acc.Name }
-func (acc *AppAccount) SetName(name string)             { acc.Name = name }
-func (acc AppAccount) GetFrozenCoins() sdk.Coins        { return acc.FrozenCoins }
-func (acc *AppAccount) SetFrozenCoins(frozen sdk.Coins) { acc.FrozenCoins = frozen }
-func (acc AppAccount) GetLockedCoins() sdk.Coins        { return acc.LockedCoins }
-func (acc *AppAccount) SetLockedCoins(frozen sdk.Coins) { acc.LockedCoins = frozen }
-func (acc *AppAccount) GetFlags() uint64                { return acc.Flags }
-func (acc *AppAccount) SetFlags(flags uint64)           { acc.Flags = flags }
+func (acc AppAccount) GetName() string           { return acc.Name }
+func (acc *AppAccount) SetName(name string)      { acc.Name = name }
+func (acc AppAccount) GetFrozenCoins() sdk.Coins { return acc.FrozenCoins }
+func (acc AppAccount) GetLockedCoins() sdk.Coins { return acc.LockedCoins }
+func (acc *AppAccount) GetFlags() uint64         { return acc.Flags }
+func (acc *AppAccount) SetFlags(flags uint64)    { acc.Flags = flags }
+
+// The coins are kept sorted by denom, so that the serialization of an account is canonical
+// whatever order the coins are set in.
+func (acc *AppAccount) SetFrozenCoins(frozen sdk.Coins) { acc.FrozenCoins = sortedCoins(frozen) }
+func (acc *AppAccount) SetLockedCoins(locked sdk.Coins) { acc.LockedCoins = sortedCoins(locked) }
+
+// SetCoins sets the free coins, it fails if the total of the free, locked, frozen and vesting coins of any denom
+// overflows.
+func (acc *AppAccount) SetCoins(coins sdk.Coins) error {
+	coins = sortedCoins(coins)
+	var vesting sdk.Coins
+	if len(acc.LockSchedule) != 0 {
+		vesting = acc.GetVestingCoins()
+	}
+	if err := checkCoinsTotal(coins, acc.LockedCoins, acc.FrozenCoins, vesting); err != nil {
+		return err
+	}
+	return acc.BaseAccount.SetCoins(coins)
+}
+
+// sortedCoins returns the coins sorted by denom, it sorts a copy if they are not sorted,
+// as the slice may still be used by the caller.
+func sortedCoins(coins sdk.Coins) sdk.Coins {
+	if sort.IsSorted(coins) {
+		return coins
+	}
+	return append(sdk.Coins{}, coins...).Sort()
+}
+
+// GetAvailableCoins returns the coins minus the locked and frozen coins of each denom, clamped at zero.
+// The denoms which are only locked or frozen are not included.
+func (acc *AppAccount) GetAvailableCoins() sdk.Coins {
+	available := sdk.Coins{}
+	for _, coin := range acc.GetCoins() {
+		amount := coin.Amount - acc.LockedCoins.AmountOf(coin.Denom) - acc.FrozenCoins.AmountOf(coin.Denom)
+		if amount > 0 {
+			available = append(available, sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+	return available.Sort()
+}
+
+func (acc *AppAccount) GetLockSchedule() []LockEntry { return acc.LockSchedule }
+
+// SetLockSchedule sets the vesting coins and when they are unlocked, the entries are kept sorted by unlock time.
+// The coins of the entries are not taken from the other balances of the account, see VestCoins for that.
+func (acc *AppAccount) SetLockSchedule(entries []LockEntry) {
+	schedule := make([]LockEntry, len(entries))
+	for i, entry := range entries {
+		schedule[i] = LockEntry{UnlockTime: entry.UnlockTime, Coins: append(sdk.Coins{}, entry.Coins...).Sort()}
+	}
+	sort.SliceStable(schedule, func(i, j int) bool { return schedule[i].UnlockTime < schedule[j].UnlockTime })
+	acc.LockSchedule = schedule
+}
+
+// GetVestingCoins returns the coins of the lock schedule which are not unlocked yet
+func (acc *AppAccount) GetVestingCoins() sdk.Coins {
+	vesting := sdk.Coins{}
+	for _, entry := range acc.LockSchedule {
+		vesting = vesting.Plus(entry.Coins)
+	}
+	return vesting
+}
+
+// UnlockMaturedCoins moves the coins of the entries whose unlock time is not after blockTime from the vesting
+// coins to the free ones, and removes the entries. It returns the coins unlocked.
+func (acc *AppAccount) UnlockMaturedCoins(blockTime int64) sdk.Coins {
+	unlocked := sdk.Coins{}
+	n := 0
+	for ; n < len(acc.LockSchedule) && acc.LockSchedule[n].UnlockTime <= blockTime; n++ {
+		unlocked = unlocked.Plus(acc.LockSchedule[n].Coins)
+	}
+	if n == 0 {
+		return unlocked
+	}
+	acc.LockSchedule = acc.LockSchedule[n:]
+	if len(acc.LockSchedule) == 0 {
+		acc.LockSchedule = nil
+	}
+
+	if err := acc.SetCoins(acc.GetCoins().Plus(unlocked)); err != nil {
+		// the total of the coins is unchanged, so it can't overflow
+		panic(err)
+	}
+	return unlocked
+}
+
+// SetFrozenUntil makes the frozen coins of the denom thawed from the height on, it replaces the former expiry
+// of the denom. A non-positive height removes the expiry, so the coins stay frozen.
+func (acc *AppAccount) SetFrozenUntil(denom string, height int64) {
+	expiries := make([]FrozenExpiry, 0, len(acc.FrozenExpiries)+1)
+	for _, expiry := range acc.FrozenExpiries {
+		if expiry.Denom != denom {
+			expiries = append(expiries, expiry)
+		}
+	}
+	if height > 0 {
+		expiries = append(expiries, FrozenExpiry{Denom: denom, Height: height})
+	}
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].Denom < expiries[j].Denom })
+	if len(expiries) == 0 {
+		expiries = nil
+	}
+	acc.FrozenExpiries = expiries
+}
+
+// ThawExpiredCoins moves the frozen coins of the denoms whose expiry height has been reached to the free coins,
+// and removes the expiries. It returns the coins thawed.
+func (acc *AppAccount) ThawExpiredCoins(height int64) sdk.Coins {
+	thawed := sdk.Coins{}
+	var expiries []FrozenExpiry
+	for _, expiry := range acc.FrozenExpiries {
+		if expiry.Height > height {
+			expiries = append(expiries, expiry)
+		} else if amount := acc.FrozenCoins.AmountOf(expiry.Denom); amount > 0 {
+			thawed = append(thawed, sdk.NewCoin(expiry.Denom, amount))
+		}
+	}
+	if len(expiries) == len(acc.FrozenExpiries) {
+		return thawed
+	}
+	acc.FrozenExpiries = expiries
+
+	thawed = thawed.Sort()
+	acc.SetFrozenCoins(acc.FrozenCoins.Minus(thawed))
+	if err := acc.SetCoins(acc.GetCoins().Plus(thawed)); err != nil {
+		// the total of the coins is unchanged, so it can't overflow
+		panic(err)
+	}
+	return thawed
+}
+
+// checkCoinsTotal merges the lists by denom in one pass and checks the total of each denom doesn't overflow.
+// The lists are kept sorted by denom, an unsorted one is sorted first.
+func checkCoinsTotal(coinsList ...sdk.Coins) error {
+	for i, coins := range coinsList {
+		coinsList[i] = sortedCoins(coins)
+	}
+	pos := make([]int, len(coinsList))
+	for {
+		// the smallest denom not merged yet
+		denom, found := "", false
+		for i, coins := range coinsList {
+			if pos[i] < len(coins) && (!found || coins[pos[i]].Denom < denom) {
+				denom, found = coins[pos[i]].Denom, true
+			}
+		}
+		if !found {
+			return nil
+		}
+		var total int64
+		for i, coins := range coinsList {
+			for ; pos[i] < len(coins) && coins[pos[i]].Denom == denom; pos[i]++ {
+				amount := coins[pos[i]].Amount
+				if (amount > 0 && total > math.MaxInt64-amount) || (amount < 0 && total < math.MinInt64-amount) {
+					return fmt.Errorf("total amount of %s overflows", denom)
+				}
+				total += amount
+			}
+		}
+	}
+}
+
 func (acc *AppAccount) Clone() sdk.Account {
 	baseAcc := acc.BaseAccount.Clone().(*auth.BaseAccount)
 	clonedAcc := &AppAccount{
@@ -58,6 +266,15 @@ func (acc *AppAccount) Clone() sdk.Account {
 		Name:        acc.Name,
 		Flags:       acc.Flags,
 	}
+	if acc.FrozenExpiries != nil {
+		clonedAcc.FrozenExpiries = append([]FrozenExpiry{}, acc.FrozenExpiries...)
+	}
+	if acc.LockSchedule != nil {
+		clonedAcc.LockSchedule = make([]LockEntry, len(acc.LockSchedule))
+		for i, entry := range acc.LockSchedule {
+			clonedAcc.LockSchedule[i] = LockEntry{UnlockTime: entry.UnlockTime, Coins: append(sdk.Coins{}, entry.Coins...)}
+		}
+	}
 	if acc.FrozenCoins == nil {
 		clonedAcc.FrozenCoins = nil
 	} else {
@@ -99,3 +316,95 @@ func ProtoAppAccount() sdk.Account {
 	aa := AppAccount{}
 	return &aa
 }
+
+// GetLockedCoinsPaged returns at most limit locked coins of the account sorted by denom, starting from startDenom.
+// nextDenom is the cursor of the next page, it's empty if this is the last page.
+func GetLockedCoinsPaged(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, startDenom string, limit int) (coins sdk.Coins, nextDenom string) {
+	acc, ok := am.GetAccount(ctx, addr).(NamedAccount)
+	if !ok || limit <= 0 {
+		return sdk.Coins{}, ""
+	}
+
+	locked := append(sdk.Coins{}, acc.GetLockedCoins()...)
+	sort.Slice(locked, func(i, j int) bool { return locked[i].Denom < locked[j].Denom })
+	start := sort.Search(len(locked), func(i int) bool { return locked[i].Denom >= startDenom })
+	end := start + limit
+	if end >= len(locked) {
+		return locked[start:], ""
+	}
+	return locked[start:end], locked[end].Denom
+}
+
+// SetAccountNumberOffset makes the account numbers assigned by the account keeper start from n, e.g. to avoid
+// collisions with the accounts imported from another chain. It fails if a number not less than n has been assigned.
+// The account keeper has no setter of the next account number, so the numbers up to n are drawn one by one,
+// which is meant to be done once, e.g. at genesis or an upgrade.
+func SetAccountNumberOffset(ctx sdk.Context, am auth.AccountKeeper, n int64) error {
+	// GetNextAccountNumber also increments the number, so it's peeked at in a discarded cache context
+	cacheCtx, _ := ctx.CacheContext()
+	next := am.GetNextAccountNumber(cacheCtx)
+	if next > n {
+		return fmt.Errorf("account number %d has been assigned, the offset %d is too small", next-1, n)
+	}
+	for ; next < n; next++ {
+		am.GetNextAccountNumber(ctx)
+	}
+	return nil
+}
+
+// FreezeCoins moves the coins from the free balance of the account to the frozen one.
+// The frozen coins are not spendable, as the fees and transfers are paid from the free balance.
+func FreezeCoins(acc NamedAccount, coins sdk.Coins) error {
+	coins = append(sdk.Coins{}, coins...).Sort()
+	if !coins.IsValid() || !coins.IsPositive() {
+		return fmt.Errorf("invalid coins to freeze: %s", coins)
+	}
+	free := acc.GetCoins().Minus(coins)
+	if !free.IsNotNegative() {
+		return fmt.Errorf("insufficient free coins to freeze: %s < %s", acc.GetCoins(), coins)
+	}
+	return moveCoins(acc, free, acc.GetFrozenCoins().Plus(coins))
+}
+
+// VestCoins moves the coins of the entries from the free balance of the account to its vesting balance,
+// they are unlocked back at the unlock times of the entries.
+func VestCoins(acc NamedAccount, entries []LockEntry) error {
+	coins := sdk.Coins{}
+	for _, entry := range entries {
+		entryCoins := append(sdk.Coins{}, entry.Coins...).Sort()
+		if !entryCoins.IsValid() || !entryCoins.IsPositive() {
+			return fmt.Errorf("invalid coins to vest: %s", entryCoins)
+		}
+		coins = coins.Plus(entryCoins)
+	}
+	free := acc.GetCoins().Minus(coins)
+	if !free.IsNotNegative() {
+		return fmt.Errorf("insufficient free coins to vest: %s < %s", acc.GetCoins(), coins)
+	}
+	if err := acc.SetCoins(free); err != nil {
+		return err
+	}
+	acc.SetLockSchedule(append(append([]LockEntry{}, acc.GetLockSchedule()...), entries...))
+	return nil
+}
+
+// UnfreezeCoins moves the coins from the frozen balance of the account back to the free one.
+func UnfreezeCoins(acc NamedAccount, coins sdk.Coins) error {
+	coins = append(sdk.Coins{}, coins...).Sort()
+	if !coins.IsValid() || !coins.IsPositive() {
+		return fmt.Errorf("invalid coins to unfreeze: %s", coins)
+	}
+	frozen := acc.GetFrozenCoins().Minus(coins)
+	if !frozen.IsNotNegative() {
+		return fmt.Errorf("insufficient frozen coins to unfreeze: %s < %s", acc.GetFrozenCoins(), coins)
+	}
+	return moveCoins(acc, acc.GetCoins().Plus(coins), frozen)
+}
+
+func moveCoins(acc NamedAccount, free, frozen sdk.Coins) error {
+	if err := checkCoinsTotal(free, acc.GetLockedCoins(), frozen); err != nil {
+		return err
+	}
+	acc.SetFrozenCoins(frozen)
+	return acc.SetCoins(free)
+}
@@ -0,0 +1,229 @@
+package types_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/bnb-chain/node/common/testutils"
+	"github.com/bnb-chain/node/common/types"
+	"github.com/bnb-chain/node/wire"
+)
+
+func setupAccountKeeper() (sdk.Context, auth.AccountKeeper) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	accountStoreCache := auth.NewAccountStoreCache(cdc, ms.GetKVStore(capKey), 10)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1},
+		sdk.RunTxModeDeliver, log.NewNopLogger()).
+		WithAccountCache(auth.NewAccountCache(accountStoreCache))
+	return ctx, am
+}
+
+func TestGetLockedCoinsPaged(t *testing.T) {
+	ctx, am := setupAccountKeeper()
+	_, acc := testutils.NewNamedAccount(ctx, am, 100)
+	acc.SetLockedCoins(sdk.Coins{
+		sdk.NewCoin("AAA-000", 1),
+		sdk.NewCoin("BBB-000", 2),
+		sdk.NewCoin("BNB", 3),
+		sdk.NewCoin("CCC-000", 4),
+		sdk.NewCoin("DDD-000", 5),
+	})
+	am.SetAccount(ctx, acc)
+
+	// first page
+	coins, next := types.GetLockedCoinsPaged(ctx, am, acc.GetAddress(), "", 2)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("AAA-000", 1), sdk.NewCoin("BBB-000", 2)}, coins)
+	require.Equal(t, "BNB", next)
+
+	// middle page
+	coins, next = types.GetLockedCoinsPaged(ctx, am, acc.GetAddress(), next, 2)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 3), sdk.NewCoin("CCC-000", 4)}, coins)
+	require.Equal(t, "DDD-000", next)
+
+	// final page
+	coins, next = types.GetLockedCoinsPaged(ctx, am, acc.GetAddress(), next, 2)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("DDD-000", 5)}, coins)
+	require.Equal(t, "", next)
+}
+
+func TestAppAccountSetCoinsOverflow(t *testing.T) {
+	acc := &types.AppAccount{}
+	acc.SetLockedCoins(sdk.Coins{sdk.NewCoin("BNB", math.MaxInt64-10)})
+
+	// near max, but the total still fits
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 10)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 10)}, acc.GetCoins())
+
+	// the total overflows, the free coins are kept unchanged
+	err := acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 11)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overflows")
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 10)}, acc.GetCoins())
+
+	// other denoms are not affected
+	acc.SetFrozenCoins(sdk.Coins{sdk.NewCoin("XYZ-000", math.MaxInt64)})
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 1), sdk.NewCoin("XYZ-000", 0)}))
+	require.Error(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("XYZ-000", 1)}))
+
+	// the free coins are sorted before they are merged with the others
+	require.Error(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("XYZ-000", 1), sdk.NewCoin("BNB", 1)}))
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("XYZ-000", 0), sdk.NewCoin("BNB", 2)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 2), sdk.NewCoin("XYZ-000", 0)}, acc.GetCoins())
+}
+
+func TestSetAccountNumberOffset(t *testing.T) {
+	ms, capKey, _ := testutils.SetupMultiStoreForUnitTest()
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	am := auth.NewAccountKeeper(cdc, capKey, types.ProtoAppAccount)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid", Height: 1}, sdk.RunTxModeDeliver, log.NewNopLogger())
+
+	require.NoError(t, types.SetAccountNumberOffset(ctx, am, 1000))
+	require.Equal(t, int64(1000), am.GetNextAccountNumber(ctx))
+	require.Equal(t, int64(1001), am.GetNextAccountNumber(ctx))
+
+	// the assigned numbers can't be reused
+	require.Error(t, types.SetAccountNumberOffset(ctx, am, 1001))
+	require.NoError(t, types.SetAccountNumberOffset(ctx, am, 1002))
+	require.Equal(t, int64(1002), am.GetNextAccountNumber(ctx))
+}
+
+func TestAppAccountCanonicalSerialization(t *testing.T) {
+	cdc := wire.NewCodec()
+	types.RegisterWire(cdc)
+	_, addr := testutils.PrivAndAddr()
+	newAccount := func(coins ...sdk.Coin) *types.AppAccount {
+		acc := &types.AppAccount{BaseAccount: auth.BaseAccount{Address: addr}, Name: "acc"}
+		require.NoError(t, acc.SetCoins(append(sdk.Coins{}, coins...)))
+		acc.SetLockedCoins(append(sdk.Coins{}, coins...))
+		acc.SetFrozenCoins(append(sdk.Coins{}, coins...))
+		return acc
+	}
+
+	acc1 := newAccount(sdk.NewCoin("BNB", 1), sdk.NewCoin("BTC-000", 2), sdk.NewCoin("ETH-000", 3))
+	acc2 := newAccount(sdk.NewCoin("ETH-000", 3), sdk.NewCoin("BNB", 1), sdk.NewCoin("BTC-000", 2))
+	bz1, err := cdc.MarshalBinaryBare(acc1)
+	require.NoError(t, err)
+	bz2, err := cdc.MarshalBinaryBare(acc2)
+	require.NoError(t, err)
+	require.Equal(t, bz1, bz2)
+
+	// the coins of the caller are left in their order
+	coins := sdk.Coins{sdk.NewCoin("ETH-000", 3), sdk.NewCoin("BNB", 1)}
+	require.NoError(t, acc1.SetCoins(coins))
+	acc1.SetLockedCoins(coins)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("ETH-000", 3), sdk.NewCoin("BNB", 1)}, coins)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 1), sdk.NewCoin("ETH-000", 3)}, acc1.GetCoins())
+}
+
+func TestAppAccountGetAvailableCoins(t *testing.T) {
+	acc := &types.AppAccount{}
+	require.NoError(t, acc.SetCoins(sdk.Coins{
+		sdk.NewCoin("BNB", 100), sdk.NewCoin("BTC-000", 10), sdk.NewCoin("ETH-000", 50),
+	}))
+	acc.SetLockedCoins(sdk.Coins{sdk.NewCoin("BNB", 30), sdk.NewCoin("BTC-000", 8), sdk.NewCoin("XRP-000", 5)})
+	acc.SetFrozenCoins(sdk.Coins{sdk.NewCoin("BNB", 20), sdk.NewCoin("BTC-000", 8), sdk.NewCoin("USD-000", 7)})
+
+	// BTC-000 is clamped at zero, XRP-000 and USD-000 are only locked or frozen
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 50), sdk.NewCoin("ETH-000", 50)}, acc.GetAvailableCoins())
+
+	var named types.NamedAccount = acc
+	require.Equal(t, acc.GetAvailableCoins(), named.GetAvailableCoins())
+}
+
+func TestFreezeCoins(t *testing.T) {
+	acc := &types.AppAccount{}
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("BTC-000", 10)}))
+
+	// more than the free coins, or not positive
+	require.Error(t, types.FreezeCoins(acc, sdk.Coins{sdk.NewCoin("BNB", 101)}))
+	require.Error(t, types.FreezeCoins(acc, sdk.Coins{sdk.NewCoin("ETH-000", 1)}))
+	require.Error(t, types.FreezeCoins(acc, sdk.Coins{sdk.NewCoin("BNB", 0)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("BTC-000", 10)}, acc.GetCoins())
+
+	require.NoError(t, types.FreezeCoins(acc, sdk.Coins{sdk.NewCoin("BTC-000", 10), sdk.NewCoin("BNB", 60)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 40)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 60), sdk.NewCoin("BTC-000", 10)}, acc.GetFrozenCoins())
+
+	require.Error(t, types.UnfreezeCoins(acc, sdk.Coins{sdk.NewCoin("BNB", 61)}))
+	require.NoError(t, types.UnfreezeCoins(acc, sdk.Coins{sdk.NewCoin("BNB", 60)}))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 100)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BTC-000", 10)}, acc.GetFrozenCoins())
+}
+
+func TestValidateAccountName(t *testing.T) {
+	for _, name := range []string{"a", "alice", "Alice-01_v2.bnb", strings.Repeat("a", 32)} {
+		require.NoError(t, types.ValidateAccountName(name), name)
+	}
+	for _, name := range []string{"", strings.Repeat("a", 33), "alice bob", "alice@bnb", "中文"} {
+		require.Error(t, types.ValidateAccountName(name), name)
+	}
+}
+
+func TestUnlockMaturedCoins(t *testing.T) {
+	acc := &types.AppAccount{}
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 110), sdk.NewCoin("BTC-000", 5)}))
+	// the locked coins of the open orders are not touched by the schedule
+	acc.SetLockedCoins(sdk.Coins{sdk.NewCoin("BNB", 8)})
+	require.NoError(t, types.VestCoins(acc, []types.LockEntry{
+		{UnlockTime: 2000, Coins: sdk.Coins{sdk.NewCoin("BNB", 70)}},
+		{UnlockTime: 1000, Coins: sdk.Coins{sdk.NewCoin("BTC-000", 5), sdk.NewCoin("BNB", 30)}},
+	}))
+	require.Equal(t, int64(1000), acc.GetLockSchedule()[0].UnlockTime)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 10)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("BTC-000", 5)}, acc.GetVestingCoins())
+	require.Error(t, types.VestCoins(acc, []types.LockEntry{{UnlockTime: 3000, Coins: sdk.Coins{sdk.NewCoin("BNB", 11)}}}))
+
+	require.Equal(t, sdk.Coins{}, acc.UnlockMaturedCoins(999))
+	require.Len(t, acc.GetLockSchedule(), 2)
+
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 30), sdk.NewCoin("BTC-000", 5)}, acc.UnlockMaturedCoins(1000))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 40), sdk.NewCoin("BTC-000", 5)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 70)}, acc.GetVestingCoins())
+	require.Len(t, acc.GetLockSchedule(), 1)
+
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 70)}, acc.UnlockMaturedCoins(5000))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 110), sdk.NewCoin("BTC-000", 5)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 8)}, acc.GetLockedCoins())
+	require.True(t, acc.GetVestingCoins().IsZero())
+	require.Empty(t, acc.GetLockSchedule())
+}
+
+func TestThawExpiredCoins(t *testing.T) {
+	acc := &types.AppAccount{}
+	require.NoError(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("BNB", 10)}))
+	acc.SetFrozenCoins(sdk.Coins{sdk.NewCoin("BNB", 100), sdk.NewCoin("BTC-000", 5), sdk.NewCoin("ETH-000", 7)})
+	acc.SetFrozenUntil("BTC-000", 20)
+	acc.SetFrozenUntil("BNB", 10)
+	require.Equal(t, "BNB", acc.FrozenExpiries[0].Denom)
+
+	require.Equal(t, sdk.Coins{}, acc.ThawExpiredCoins(9))
+	require.Len(t, acc.FrozenExpiries, 2)
+
+	// only BNB has expired, ETH-000 has no expiry at all
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 100)}, acc.ThawExpiredCoins(10))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 110)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BTC-000", 5), sdk.NewCoin("ETH-000", 7)}, acc.GetFrozenCoins())
+	require.Len(t, acc.FrozenExpiries, 1)
+
+	// a later expiry replaces the former one
+	acc.SetFrozenUntil("BTC-000", 30)
+	require.Equal(t, sdk.Coins{}, acc.ThawExpiredCoins(25))
+
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BTC-000", 5)}, acc.ThawExpiredCoins(30))
+	require.Equal(t, sdk.Coins{sdk.NewCoin("BNB", 110), sdk.NewCoin("BTC-000", 5)}, acc.GetCoins())
+	require.Equal(t, sdk.Coins{sdk.NewCoin("ETH-000", 7)}, acc.GetFrozenCoins())
+	require.Empty(t, acc.FrozenExpiries)
+}
@@ -28,6 +28,46 @@ const (
 	NativeTokenTotalSupply        = 2e16
 )
 
+var (
+	nativeToken       = NativeTokenSymbol
+	nativeTokenSet    bool
+	nativeTokenLocked bool
+)
+
+// NativeToken returns the symbol of the native token the fees are charged in,
+// NativeTokenSymbol unless it's overridden by SetNativeToken.
+func NativeToken() string {
+	return nativeToken
+}
+
+// SetNativeToken overrides the symbol of the native token, e.g. for a fork or a testnet.
+// It can only be called once, at app init before the genesis is loaded.
+func SetNativeToken(symbol string) {
+	if nativeTokenLocked {
+		panic("native token can't be changed after the genesis is loaded")
+	}
+	if nativeTokenSet {
+		panic(fmt.Sprintf("native token is already set to %s", nativeToken))
+	}
+	if len(symbol) < TokenSymbolNewMinLen || len(symbol) > TokenSymbolMaxLen || !utils.IsAlphaNum(symbol) {
+		panic(fmt.Sprintf("invalid native token symbol %q", symbol))
+	}
+	nativeToken = symbol
+	nativeTokenSet = true
+}
+
+// LockNativeToken makes SetNativeToken panic from now on, it's called when the genesis is loaded
+func LockNativeToken() {
+	nativeTokenLocked = true
+}
+
+// UnsetNativeToken restores NativeTokenSymbol as the native token and unlocks it, for tests
+func UnsetNativeToken() {
+	nativeToken = NativeTokenSymbol
+	nativeTokenSet = false
+	nativeTokenLocked = false
+}
+
 type IToken interface {
 	GetName() string
 	GetSymbol() string
@@ -177,7 +217,8 @@ func ValidateTokenSymbol(symbol string) error {
 
 	// suffix exception for native token (less drama in existing tests)
 	if symbol == NativeTokenSymbol ||
-		symbol == NativeTokenSymbolDotBSuffixed {
+		symbol == NativeTokenSymbolDotBSuffixed ||
+		symbol == NativeToken() {
 		return nil
 	}
 
@@ -147,3 +147,21 @@ func TestMarshalToken(t *testing.T) {
 	require.Equal(t, string(emptyBeforeToken), string(emptyToken))
 	require.Equal(t, string(emptyBeforeMiniToken), string(emptyMiniToken))
 }
+
+func TestSetNativeToken(t *testing.T) {
+	defer types.UnsetNativeToken()
+	require.Equal(t, types.NativeTokenSymbol, types.NativeToken())
+	require.Error(t, types.ValidateTokenSymbol("TBNB"))
+
+	require.Panics(t, func() { types.SetNativeToken("T-BNB") })
+	types.SetNativeToken("TBNB")
+	require.Equal(t, "TBNB", types.NativeToken())
+	require.NoError(t, types.ValidateTokenSymbol("TBNB"))
+	// it can only be set once
+	require.Panics(t, func() { types.SetNativeToken("XBNB") })
+
+	types.UnsetNativeToken()
+	types.LockNativeToken()
+	require.Panics(t, func() { types.SetNativeToken("TBNB") })
+	require.Equal(t, types.NativeTokenSymbol, types.NativeToken())
+}
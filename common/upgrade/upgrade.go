@@ -53,6 +53,11 @@ const (
 	FirstSunset                 = sdk.FirstSunsetFork  // https://github.com/bnb-chain/BEPs/pull/333 BNB Chain Fusion
 	SecondSunset                = sdk.SecondSunsetFork // https://github.com/bnb-chain/BEPs/pull/333 BNB Chain Fusion
 	FinalSunset                 = sdk.FinalSunsetFork  // https://github.com/bnb-chain/BEPs/pull/333 BNB Chain Fusion
+
+	FixFeeDistribution = "FixFeeDistribution"
+	RecipientPaysFee   = "RecipientPaysFee"   // let the recipient of a HTLT pay its fee
+	DesignatedFeePayer = "DesignatedFeePayer" // let another signer pay the fee of a SetAccountFlagsMsg
+	AccountNameCheck   = "AccountNameCheck"   // reject the txs from the accounts with invalid names
 )
 
 func UpgradeBEP10(before func(), after func()) {
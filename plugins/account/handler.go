@@ -6,6 +6,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
+	"github.com/bnb-chain/node/common/tx"
 	common "github.com/bnb-chain/node/common/types"
 )
 
@@ -32,3 +33,21 @@ func handleSetAccountFlags(ctx sdk.Context, accKeeper auth.AccountKeeper, msg Se
 	accKeeper.SetAccount(ctx, account)
 	return sdk.Result{}
 }
+
+// NewFeeConsentHandler creates a handler recording the consents of the recipients to pay the transfer fees
+func NewFeeConsentHandler(keeper tx.FeeConsentKeeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case FeeConsentMsg:
+			if msg.Revoke {
+				keeper.RevokeFeePayment(ctx, msg.From, msg.Sender)
+			} else {
+				keeper.AuthorizeFeePayment(ctx, msg.From, msg.Sender)
+			}
+			return sdk.Result{}
+		default:
+			errMsg := fmt.Sprintf("unrecognized message type: %T", msg)
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package account
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/bnb-chain/node/common/upgrade"
 )
 
 const (
@@ -17,6 +20,9 @@ var _ sdk.Msg = SetAccountFlagsMsg{}
 type SetAccountFlagsMsg struct {
 	From  sdk.AccAddress `json:"from"`
 	Flags uint64         `json:"flags"`
+	// the signer paying the fee instead of From, e.g. a relayer. It's left out of the sign bytes when not set,
+	// so the msgs signed before it was added are still valid.
+	FeePayer sdk.AccAddress `json:"fee_payer,omitempty"`
 }
 
 func NewSetAccountFlagsMsg(from sdk.AccAddress, flags uint64) SetAccountFlagsMsg {
@@ -32,12 +38,32 @@ func (msg SetAccountFlagsMsg) String() string {
 	return fmt.Sprintf("setAccountFlags{%v#%v}", msg.From, msg.Flags)
 }
 func (msg SetAccountFlagsMsg) GetInvolvedAddresses() []sdk.AccAddress { return msg.GetSigners() }
-func (msg SetAccountFlagsMsg) GetSigners() []sdk.AccAddress           { return []sdk.AccAddress{msg.From} }
+func (msg SetAccountFlagsMsg) GetSigners() []sdk.AccAddress {
+	if len(msg.FeePayer) == 0 {
+		return []sdk.AccAddress{msg.From}
+	}
+	return []sdk.AccAddress{msg.From, msg.FeePayer}
+}
+
+// GetFeePayer implements tx.FeePayerMsg
+func (msg SetAccountFlagsMsg) GetFeePayer() sdk.AccAddress { return msg.FeePayer }
 
 func (msg SetAccountFlagsMsg) ValidateBasic() sdk.Error {
 	if len(msg.From) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
 	}
+	if len(msg.FeePayer) == 0 {
+		return nil
+	}
+	if !sdk.IsUpgrade(upgrade.DesignatedFeePayer) {
+		return sdk.ErrUnknownRequest("The fee payer can't be designated before the DesignatedFeePayer upgrade")
+	}
+	if len(msg.FeePayer) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.FeePayer)))
+	}
+	if bytes.Equal(msg.FeePayer, msg.From) {
+		return sdk.ErrInvalidAddress("The fee payer should not be the sender")
+	}
 	return nil
 }
 
@@ -48,3 +74,58 @@ func (msg SetAccountFlagsMsg) GetSignBytes() []byte {
 	}
 	return b
 }
+
+const (
+	FeeConsentRoute   = "feeConsent"
+	FeeConsentMsgType = "feeConsent"
+)
+
+var _ sdk.Msg = FeeConsentMsg{}
+
+// FeeConsentMsg lets From pay the fees of the transfers from Sender that ask for the fee to be paid by the
+// recipient, or revokes it.
+type FeeConsentMsg struct {
+	From   sdk.AccAddress `json:"from"`
+	Sender sdk.AccAddress `json:"sender"`
+	Revoke bool           `json:"revoke"`
+}
+
+func NewFeeConsentMsg(from, sender sdk.AccAddress, revoke bool) FeeConsentMsg {
+	return FeeConsentMsg{
+		From:   from,
+		Sender: sender,
+		Revoke: revoke,
+	}
+}
+
+func (msg FeeConsentMsg) Route() string { return FeeConsentRoute }
+func (msg FeeConsentMsg) Type() string  { return FeeConsentMsgType }
+func (msg FeeConsentMsg) String() string {
+	return fmt.Sprintf("feeConsent{%v#%v#%v}", msg.From, msg.Sender, msg.Revoke)
+}
+func (msg FeeConsentMsg) GetInvolvedAddresses() []sdk.AccAddress { return msg.GetSigners() }
+func (msg FeeConsentMsg) GetSigners() []sdk.AccAddress           { return []sdk.AccAddress{msg.From} }
+
+func (msg FeeConsentMsg) ValidateBasic() sdk.Error {
+	if !sdk.IsUpgrade(upgrade.RecipientPaysFee) {
+		return sdk.ErrUnknownRequest("The fee consent is not supported before the RecipientPaysFee upgrade")
+	}
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if len(msg.Sender) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.Sender)))
+	}
+	if bytes.Equal(msg.From, msg.Sender) {
+		return sdk.ErrInvalidAddress("The sender should not be the recipient")
+	}
+	return nil
+}
+
+func (msg FeeConsentMsg) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -3,13 +3,14 @@ package account
 import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 
+	"github.com/bnb-chain/node/common/tx"
 	app "github.com/bnb-chain/node/common/types"
 	"github.com/bnb-chain/node/plugins/account/scripts"
 )
 
-func InitPlugin(appp app.ChainApp, accountKeeper auth.AccountKeeper) {
+func InitPlugin(appp app.ChainApp, accountKeeper auth.AccountKeeper, feeConsentKeeper tx.FeeConsentKeeper) {
 	// add msg handlers
-	for route, handler := range routes(accountKeeper) {
+	for route, handler := range routes(accountKeeper, feeConsentKeeper) {
 		appp.GetRouter().AddRoute(route, handler)
 	}
 
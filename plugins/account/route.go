@@ -3,10 +3,13 @@ package account
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/bnb-chain/node/common/tx"
 )
 
-func routes(accKeeper auth.AccountKeeper) map[string]sdk.Handler {
+func routes(accKeeper auth.AccountKeeper, feeConsentKeeper tx.FeeConsentKeeper) map[string]sdk.Handler {
 	routes := make(map[string]sdk.Handler)
 	routes[AccountFlagsRoute] = NewHandler(accKeeper)
+	routes[FeeConsentRoute] = NewFeeConsentHandler(feeConsentKeeper)
 	return routes
 }
@@ -7,4 +7,5 @@ import (
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterConcrete(SetAccountFlagsMsg{}, "scripts/SetAccountFlagsMsg", nil)
+	cdc.RegisterConcrete(FeeConsentMsg{}, "scripts/FeeConsentMsg", nil)
 }
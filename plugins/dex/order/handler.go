@@ -95,7 +95,9 @@ func validateQtyAndLockBalance(ctx sdk.Context, keeper *DexKeeper, acc common.Na
 		toLockCoins = sdk.Coins{{Denom: baseAssetSymbol, Amount: msg.Quantity}}
 	}
 
-	_ = acc.SetCoins(freeBalance.Minus(toLockCoins))
+	if err := acc.SetCoins(freeBalance.Minus(toLockCoins)); err != nil {
+		return err
+	}
 	acc.SetLockedCoins(acc.GetLockedCoins().Plus(toLockCoins))
 	keeper.am.SetAccount(ctx, acc)
 	return nil
@@ -207,7 +209,9 @@ func handleCancelOrder(
 	if !transfer.FeeFree() {
 		acc := dexKeeper.am.GetAccount(ctx, msg.Sender)
 		fee = dexKeeper.FeeManager.CalcFixedFee(acc.GetCoins(), transfer.eventType, transfer.inAsset, dexKeeper.GetEngines())
-		_ = acc.SetCoins(acc.GetCoins().Minus(fee.Tokens))
+		if err := acc.SetCoins(acc.GetCoins().Minus(fee.Tokens)); err != nil {
+			return sdk.ErrInternal(err.Error()).Result()
+		}
 		dexKeeper.am.SetAccount(ctx, acc)
 	}
 
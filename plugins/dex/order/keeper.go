@@ -495,7 +495,9 @@ func (kp *DexKeeper) doTransfer(ctx sdk.Context, tran *Transfer) sdk.Error {
 	if remain := tran.unlock - tran.out; remain > 0 || !sdk.IsUpgrade(upgrade.FixZeroBalance) {
 		accountCoin = accountCoin.Plus(sdk.Coins{sdk.NewCoin(tran.outAsset, remain)})
 	}
-	account.SetCoins(accountCoin)
+	if err := account.SetCoins(accountCoin); err != nil {
+		return sdk.ErrInternal(err.Error())
+	}
 
 	kp.am.SetAccount(ctx, account)
 	return nil
@@ -573,7 +575,9 @@ func (kp *DexKeeper) allocate(ctx sdk.Context, tranCh <-chan Transfer, postAlloc
 		fees := kp.FeeManager.CalcTradesFee(acc.GetCoins(), trans, kp.engines)
 		if !fees.IsEmpty() {
 			feesPerAcc[addrStr] = &fees
-			acc.SetCoins(acc.GetCoins().Minus(fees.Tokens))
+			if err := acc.SetCoins(acc.GetCoins().Minus(fees.Tokens)); err != nil {
+				panic(err)
+			}
 			kp.am.SetAccount(ctx, acc)
 			totalFee.AddFee(fees)
 		}
@@ -590,7 +594,9 @@ func (kp *DexKeeper) allocate(ctx sdk.Context, tranCh <-chan Transfer, postAlloc
 			} else {
 				feesPerAcc[addrStr] = &fees
 			}
-			acc.SetCoins(acc.GetCoins().Minus(fees.Tokens))
+			if err := acc.SetCoins(acc.GetCoins().Minus(fees.Tokens)); err != nil {
+				panic(err)
+			}
 			kp.am.SetAccount(ctx, acc)
 			totalFee.AddFee(fees)
 		}
@@ -664,7 +670,9 @@ func (kp *DexKeeper) allocateBeforeGalileo(ctx sdk.Context, tranCh <-chan Transf
 	}
 	collectFee(tradeInAsset, func(acc sdk.Account, in sdk.Coin) sdk.Fee {
 		fee := kp.FeeManager.CalcTradeFee(acc.GetCoins(), in, kp.engines)
-		acc.SetCoins(acc.GetCoins().Minus(fee.Tokens))
+		if err := acc.SetCoins(acc.GetCoins().Minus(fee.Tokens)); err != nil {
+			panic(err)
+		}
 		return fee
 	})
 	collectFee(expireInAsset, func(acc sdk.Account, in sdk.Coin) sdk.Fee {
@@ -672,7 +680,9 @@ func (kp *DexKeeper) allocateBeforeGalileo(ctx sdk.Context, tranCh <-chan Transf
 		var fees sdk.Fee
 		for ; i < in.Amount; i++ {
 			fee := kp.FeeManager.CalcFixedFee(acc.GetCoins(), expireEventType, in.Denom, kp.engines)
-			acc.SetCoins(acc.GetCoins().Minus(fee.Tokens))
+			if err := acc.SetCoins(acc.GetCoins().Minus(fee.Tokens)); err != nil {
+				panic(err)
+			}
 			fees.AddFee(fee)
 		}
 		return fees
@@ -1053,6 +1063,20 @@ func (kp *DexKeeper) GetAllOrders() map[string]map[string]*OrderInfo {
 	return allOrders
 }
 
+// HasOpenOrders tells whether the account has open orders on any pair, whose quantities are still locked
+func (kp *DexKeeper) HasOpenOrders(addr sdk.AccAddress) bool {
+	for _, orderKeeper := range kp.OrderKeepers {
+		for _, orders := range orderKeeper.getAllOrders() {
+			for _, ord := range orders {
+				if ord.Sender.Equals(addr) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 // ONLY FOR TEST USE
 func (kp *DexKeeper) GetAllOrdersForPair(symbol string) map[string]*OrderInfo {
 	return kp.mustGetOrderKeeper(symbol).getAllOrdersForPair(symbol)
@@ -809,6 +809,8 @@ func addOrderAfterMatch(t *testing.T, symbol string) {
 	keeper.AddOrder(orderInfo, false)
 	res := keeper.GetOpenOrders(pair, zc)
 	assert.Equal(1, len(res))
+	assert.True(keeper.HasOpenOrders(zc))
+	assert.False(keeper.HasOpenOrders(zz))
 	assert.Equal(pair, res[0].Symbol)
 	assert.Equal(ZcAddr+"-0", res[0].Id)
 	assert.Equal(utils.Fixed8(0), res[0].CumQty)
@@ -863,6 +865,8 @@ func addOrderAfterMatch(t *testing.T, symbol string) {
 	assert.Equal(0, len(res))
 	res = keeper.GetOpenOrders(pair, zz)
 	assert.Equal(0, len(res))
+	assert.False(keeper.HasOpenOrders(zc))
+	assert.False(keeper.HasOpenOrders(zz))
 }
 
 func TestKeeper_DelistTradingPair(t *testing.T) {
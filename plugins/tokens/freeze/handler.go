@@ -55,7 +55,9 @@ func handleFreezeToken(ctx sdk.Context, tokenMapper store.Mapper, accKeeper auth
 	newFrozenTokens := account.GetFrozenCoins().Plus(sdk.Coins{{Denom: symbol, Amount: freezeAmount}})
 	newFreeTokens := account.GetCoins().Minus(sdk.Coins{{Denom: symbol, Amount: freezeAmount}})
 	account.SetFrozenCoins(newFrozenTokens)
-	_ = account.SetCoins(newFreeTokens)
+	if err := account.SetCoins(newFreeTokens); err != nil {
+		return sdk.ErrInvalidCoins(err.Error()).Result()
+	}
 	accKeeper.SetAccount(ctx, account)
 	logger.Info("finish freezing token", "NewFrozenToken", newFrozenTokens, "NewFreeTokens", newFreeTokens)
 	return sdk.Result{}
@@ -84,7 +86,9 @@ func handleUnfreezeToken(ctx sdk.Context, tokenMapper store.Mapper, accKeeper au
 	newFrozenTokens := account.GetFrozenCoins().Minus(sdk.Coins{{Denom: symbol, Amount: unfreezeAmount}})
 	newFreeTokens := account.GetCoins().Plus(sdk.Coins{{Denom: symbol, Amount: unfreezeAmount}})
 	account.SetFrozenCoins(newFrozenTokens)
-	_ = account.SetCoins(newFreeTokens)
+	if err := account.SetCoins(newFreeTokens); err != nil {
+		return sdk.ErrInvalidCoins(err.Error()).Result()
+	}
 	accKeeper.SetAccount(ctx, account)
 	logger.Debug("finish unfreezing token", "NewFrozenToken", newFrozenTokens, "NewFreeTokens", newFreeTokens)
 	return sdk.Result{}
@@ -39,6 +39,9 @@ type HTLTMsg struct {
 	ExpectedIncome      string         `json:"expected_income"`
 	HeightSpan          int64          `json:"height_span"`
 	CrossChain          bool           `json:"cross_chain"`
+	// the fee is paid by To if set, which must have authorized paying the fees of the HTLTs from From.
+	// It's left out of the sign bytes when not set, so the HTLTs signed before it was added are still valid.
+	RecipientPaysFee bool `json:"recipient_pays_fee,omitempty"`
 }
 
 func NewHTLTMsg(from, to sdk.AccAddress, recipientOtherChain, senderOtherChain string, randomNumberHash SwapBytes, timestamp int64,
@@ -68,6 +71,10 @@ func (msg HTLTMsg) GetInvolvedAddresses() []sdk.AccAddress {
 }
 func (msg HTLTMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.From} }
 
+// FeePaidByRecipient and GetRecipient implement tx.RecipientFeeMsg
+func (msg HTLTMsg) FeePaidByRecipient() bool     { return msg.RecipientPaysFee }
+func (msg HTLTMsg) GetRecipient() sdk.AccAddress { return msg.To }
+
 func (msg HTLTMsg) ValidateBasic() sdk.Error {
 	if len(msg.From) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
@@ -102,6 +109,9 @@ func (msg HTLTMsg) ValidateBasic() sdk.Error {
 	if msg.HeightSpan < MinimumHeightSpan || msg.HeightSpan > MaximumHeightSpan {
 		return ErrInvalidHeightSpan("The height span should be no less than 360 and no greater than 518400")
 	}
+	if msg.RecipientPaysFee && !sdk.IsUpgrade(upgrade.RecipientPaysFee) {
+		return sdk.ErrUnknownRequest("The fee can't be paid by the recipient before the RecipientPaysFee upgrade")
+	}
 
 	if sdk.IsUpgrade(upgrade.BEP8) {
 		symbolError := types.ValidateTokenSymbols(msg.Amount)